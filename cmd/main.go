@@ -1,272 +1,265 @@
 package main
 
 import (
+	"L0WB/internal/app"
 	"L0WB/internal/config"
-	ogen_server "L0WB/internal/generated/servers/http/ordergen"
-	handler "L0WB/internal/handler/http"
+	"L0WB/internal/domain"
+	"L0WB/internal/generator"
 	"L0WB/internal/kafka"
-	"L0WB/internal/repository/order"
-	"L0WB/internal/service"
-	"L0WB/internal/storage"
+	"L0WB/internal/loadgen"
+	"L0WB/internal/logger"
+	"L0WB/internal/migrate"
 	"context"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
-	"log"
-	"net/http"
+	"log/slog"
 	"os"
 	"os/signal"
-	"path/filepath"
-	"strconv"
-	"strings"
-	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
 
+const usage = `Usage: order-service <command> [flags]
+
+Commands:
+  serve-api   HTTP API без consumer
+  consume     consumer Kafka (HTTP только для /metrics и health-check)
+  generate    отправить тестовые заказы в Kafka и завершиться
+  loadgen     нагрузочный генератор: заданная скорость, длительность, профиль всплесков
+  migrate     миграции БД: up (по умолчанию), down, status, version, redo, reset
+  offsets     сброс оффсетов группы consumer: offsets reset (консьюмеры группы должны быть остановлены)
+  all         API и consumer в одном процессе (локальный запуск)
+`
+
+func fatal(log *slog.Logger, msg string, err error) {
+	log.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
-	// Проверяем наличие web директории
-	if _, err := os.Stat("./web"); os.IsNotExist(err) {
-		log.Fatal("Web directory not found! Create web/ folder with index.html, style.css, script.js")
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
 	}
+	command, args := os.Args[1], os.Args[2:]
 
-	// Проверяем наличие необходимых файлов
-	requiredFiles := []string{"index.html", "style.css", "script.js"}
-	for _, file := range requiredFiles {
-		if _, err := os.Stat(filepath.Join("./web", file)); os.IsNotExist(err) {
-			log.Printf("Warning: web/%s not found", file)
-		}
+	cfg, err := config.Load()
+	if err != nil {
+		fatal(slog.Default(), "Config error", err)
 	}
 
-	var cfg config.Config
-	ctx := context.Background()
-
-	// Инициализирую БД
-	conn, err := pgxpool.New(context.Background(), cfg.PgDSN)
+	log, err := logger.New(os.Stdout, logger.Config{
+		Level:  cfg.LogLevel,
+		Format: cfg.LogFormat,
+	})
 	if err != nil {
-		log.Fatal("Database connection error: ", err)
+		fatal(slog.Default(), "Logger initialization error", err)
 	}
-	defer conn.Close()
-
-	// Инициализирую Producer
-	kafkaBrokers := []string{cfg.KafkaBrokers}
-	kafkaTopic := cfg.KafkaTopic
-	kafkaProducer := kafka.NewOrderProducer(kafkaBrokers, kafkaTopic)
-	defer kafkaProducer.Close()
-
-	// Создаем генератор
-	orderGenerator := &kafka.OrderGeneratorImpl{}
-
-	// Инициализация кеша с TTL 1 час
-	orderCache := storage.NewOrderCache(1 * time.Hour)
-
-	// Инициализирую Репозиторий и Сервис
-	repository := order.NewRepository(conn)
-	orderService := service.NewService(repository, orderCache, orderGenerator, kafkaProducer)
-
-	// Прогрев кеша
-	warmupCtx, warmupCancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer warmupCancel()
-
-	if err := orderService.WarmUpCache(warmupCtx); err != nil {
-		log.Printf("WarmUpCache failed: %v", err)
-	} else {
-		log.Printf("WarmUpCache size: %d", orderCache.Size())
+	slog.SetDefault(log)
+
+	// Root-контекст отменяется по SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch command {
+	case "serve-api":
+		cfg.APIEnabled, cfg.ConsumerEnabled, cfg.GeneratorEnabled, cfg.AutoGenerate = true, false, false, false
+		err = serve(ctx, cfg, log)
+	case "consume":
+		cfg.APIEnabled, cfg.ConsumerEnabled, cfg.GeneratorEnabled, cfg.AutoGenerate = false, true, false, false
+		err = serve(ctx, cfg, log)
+	case "all":
+		cfg.APIEnabled, cfg.ConsumerEnabled, cfg.GeneratorEnabled = true, true, true
+		err = serve(ctx, cfg, log)
+	case "generate":
+		err = generate(ctx, cfg, log, args)
+	case "loadgen":
+		err = runLoad(ctx, cfg, log, args)
+	case "migrate":
+		err = runMigrations(ctx, cfg, log, args)
+	case "offsets":
+		err = runOffsets(ctx, cfg, log, args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
 	}
 
-	api := handler.NewHandler(orderService)
-
-	srv, err := ogen_server.NewServer(api)
 	if err != nil {
-		log.Fatal("Server creation error: ", err)
+		fatal(log, command+" failed", err)
 	}
+}
 
-	mux := http.NewServeMux()
-
-	webDir := "./web"
-	fileServer := http.FileServer(http.Dir(webDir))
-
-	// Обработка статических файлов
-	mux.Handle("/web/", http.StripPrefix("/web/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasSuffix(r.URL.Path, ".js") {
-			w.Header().Set("Content-Type", "application/javascript")
-		} else if strings.HasSuffix(r.URL.Path, ".css") {
-			w.Header().Set("Content-Type", "text/css")
-		}
-		fileServer.ServeHTTP(w, r)
-	})))
+func serve(ctx context.Context, cfg config.Config, log *slog.Logger) error {
+	application, err := app.New(ctx, cfg, log)
+	if err != nil {
+		return fmt.Errorf("application initialization: %w", err)
+	}
 
-	// API endpoint для генерации заказов
-	mux.HandleFunc("/generate", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != "POST" {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+	if err := application.Run(ctx); err != nil {
+		return err
+	}
 
-		countStr := r.URL.Query().Get("count")
-		count, err := strconv.Atoi(countStr)
-		if err != nil || count <= 0 {
-			count = 1
-		}
+	log.Info("Server stopped gracefully")
+	return nil
+}
 
-		log.Printf("Generating %d test orders", count)
+func generate(ctx context.Context, cfg config.Config, log *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	count := flags.Int("count", 1, "number of orders to publish")
+	newGenerator := generatorFlags(flags, cfg)
+	flags.Parse(args)
 
-		orders := kafka.GenerateFakeOrders(count)
-		generatedCount := 0
+	orders, seed, err := newGenerator()
+	if err != nil {
+		return err
+	}
 
-		for _, order := range orders {
-			if order != nil {
-				err := kafkaProducer.SendOrder(r.Context(), order)
-				if err != nil {
-					log.Printf("Error sending order: %v", err)
-				} else {
-					generatedCount++
-					log.Printf("Generated order: %s", order.OrderUID)
-				}
-			}
-		}
+	producer, err := kafka.NewOrderProducer(app.ProducerConfig(cfg), log)
+	if err != nil {
+		return err
+	}
+	defer producer.Close()
 
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(fmt.Sprintf(`{"success": true, "generated": %d}`, generatedCount)))
-	})
+	batch := make([]*domain.CompleteFakeOrder, 0, *count)
+	for len(batch) < *count {
+		batch = append(batch, orders.GenerateFakeOrder())
+	}
+	if err := producer.SendOrders(ctx, batch); err != nil {
+		return err
+	}
 
-	// API endpoint для получения заказа по ID
-	mux.HandleFunc("/order/get-order/", func(w http.ResponseWriter, r *http.Request) {
-		// CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	log.Info("orders published", "count", len(batch), "topic", cfg.KafkaTopic, "seed", seed)
+	return nil
+}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+func runLoad(ctx context.Context, cfg config.Config, log *slog.Logger, args []string) error {
+	var loadCfg loadgen.Config
+	flags := flag.NewFlagSet("loadgen", flag.ExitOnError)
+	flags.Float64Var(&loadCfg.Rate, "rate", 100, "target rate, messages per second")
+	flags.DurationVar(&loadCfg.Duration, "duration", time.Minute, "run duration")
+	flags.IntVar(&loadCfg.Concurrency, "concurrency", 4, "number of concurrent senders")
+	flags.StringVar(&loadCfg.Profile, "profile", loadgen.ProfileConstant, "load profile: constant, burst or ramp")
+	flags.Float64Var(&loadCfg.BurstFactor, "burst-factor", 5, "rate multiplier during a burst")
+	flags.DurationVar(&loadCfg.BurstEvery, "burst-every", 10*time.Second, "interval between bursts")
+	flags.DurationVar(&loadCfg.BurstLength, "burst-length", 2*time.Second, "burst length")
+	newGenerator := generatorFlags(flags, cfg)
+	flags.Parse(args)
+
+	orders, seed, err := newGenerator()
+	if err != nil {
+		return err
+	}
 
-		if r.Method != "GET" {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	//В асинхронном режиме SendOrder только ставит сообщение в очередь, итог доставки считаем по отчетам
+	var delivered, undelivered atomic.Int64
+	producerCfg := app.ProducerConfig(cfg)
+	producerCfg.OnDelivery = func(report kafka.DeliveryReport) {
+		if report.Err != nil {
+			undelivered.Add(1)
 			return
 		}
+		delivered.Add(1)
+	}
+	producer, err := kafka.NewOrderProducer(producerCfg, log)
+	if err != nil {
+		return err
+	}
 
-		// Извлекаем UUID из URL: /order/get-order/{uuid}
-		pathParts := strings.Split(r.URL.Path, "/")
-		if len(pathParts) < 4 {
-			http.Error(w, "Invalid URL", http.StatusBadRequest)
-			return
-		}
+	load, err := loadgen.NewGenerator(loadCfg, orders.GenerateFakeOrder, producer, log)
+	if err != nil {
+		producer.Close()
+		return err
+	}
 
-		orderUID := pathParts[3]
-		log.Printf("GET order request: %s", orderUID)
+	log.Info("load generation started", "seed", seed, "rate", loadCfg.Rate, "duration", loadCfg.Duration,
+		"concurrency", loadCfg.Concurrency, "profile", loadCfg.Profile)
+	summary := load.Run(ctx)
+	if err := producer.Close(); err != nil {
+		log.Error("producer close failed", "error", err)
+	}
+	fmt.Printf("seed=%d %s\ndelivered=%d undelivered=%d\n", seed, summary, delivered.Load(), undelivered.Load())
+	return nil
+}
 
-		id, err := uuid.Parse(orderUID)
-		if err != nil {
-			http.Error(w, "Invalid order UUID", http.StatusBadRequest)
-			return
+// generatorFlags добавляет флаги генератора заказов; значения по умолчанию берутся из конфигурации.
+// Возвращаемая функция собирает выбранную стратегию и сообщает итоговый seed
+func generatorFlags(flags *flag.FlagSet, cfg config.Config) func() (generator.Strategy, int64, error) {
+	settings := app.GeneratorSettings(cfg)
+	flags.StringVar(&settings.Strategy, "strategy", settings.Strategy, "generator strategy: random, replay or template")
+	flags.Int64Var(&settings.Seed, "seed", settings.Seed, "random seed, 0 picks one from the clock")
+	flags.StringVar(&settings.Dictionaries, "dictionaries", settings.Dictionaries, "JSON file with generator dictionaries")
+	flags.Float64Var(&settings.InvalidRate, "invalid-rate", settings.InvalidRate, "share of deliberately invalid orders, 0..1")
+	flags.StringVar(&settings.ReplayFile, "replay-file", settings.ReplayFile, "JSON or JSON Lines file with orders for the replay strategy")
+	flags.StringVar(&settings.TemplateFile, "template-file", settings.TemplateFile, "JSON order for the template strategy")
+
+	return func() (generator.Strategy, int64, error) {
+		if settings.Seed == 0 {
+			settings.Seed = time.Now().UnixNano()
 		}
-
-		order, err := orderService.GetOrder(r.Context(), id)
+		orders, err := generator.FromSettings(settings)
 		if err != nil {
-			http.Error(w, "Order not found", http.StatusNotFound)
-			return
+			return nil, 0, err
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": true,
-			"data":    order,
-			"cached":  false,
-		})
-	})
-
-	mux.Handle("/order/", srv)
-
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		// Если запрос к статическим файлам
-		if strings.HasPrefix(r.URL.Path, "/web/") {
-			fileServer.ServeHTTP(w, r)
-			return
-		}
-
-		if strings.HasPrefix(r.URL.Path, "/order/get-order/") ||
-			strings.HasPrefix(r.URL.Path, "/generate") ||
-			strings.HasPrefix(r.URL.Path, "/order/order/") {
-			mux.ServeHTTP(w, r)
-			return
-		}
-
-		http.ServeFile(w, r, filepath.Join(webDir, "index.html"))
-	})
-
-	// Запускаю обработчик Graceful Shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	server := http.Server{
-		Addr:    cfg.ServerPort,
-		Handler: mux,
+		strategy, err := orders.Strategy(settings.Strategy)
+		return strategy, settings.Seed, err
 	}
+}
 
-	var wg sync.WaitGroup
-
-	// Инициализирую Consumer
-	kafkaConsumer := kafka.NewOrderConsumer(
-		kafkaBrokers,
-		kafkaTopic,
-		"order-service-group",
-		orderService,
-	)
-	defer kafkaConsumer.Close()
-
-	// Запуск Consumer в горутине
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		kafkaConsumer.Consume(ctx)
-	}()
-
-	// Запускаю сервер в горутине
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		log.Println("Server starting on :8081")
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("HTTP server error: %v", err)
-		}
-	}()
-
-	// Авто-генерация тестового заказа через 3 секунды
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		time.Sleep(3 * time.Second)
-		log.Println("Auto-generating test order...")
-		orders := kafka.GenerateFakeOrders(1)
-		for _, order := range orders {
-			if order != nil {
-				err := kafkaProducer.SendOrder(context.Background(), order)
-				if err != nil {
-					log.Printf("Error auto-generating order: %v", err)
-				} else {
-					log.Printf("Auto-generated order: %s", order.OrderUID)
-				}
-			}
-		}
-	}()
-
-	<-quit
-	log.Println("Shutting down server...")
+func runMigrations(ctx context.Context, cfg config.Config, log *slog.Logger, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	return migrate.Run(ctx, cfg.PgDSN, command, args, log)
+}
 
-	// Graceful shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
+const offsetsUsage = "usage: offsets reset [-topic <topic>] [-group <id>] (-to <earliest|latest|offset|RFC3339 time> | -partitions <p=offset,...>) [-dry-run]"
 
-	// Останавливаю HTTP сервер
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Printf("HTTP server shutdown error: %v", err)
+func runOffsets(ctx context.Context, cfg config.Config, log *slog.Logger, args []string) error {
+	if len(args) == 0 || args[0] != "reset" {
+		return errors.New(offsetsUsage)
 	}
 
-	// Ждем завершения всех горутин
-	wg.Wait()
+	flags := flag.NewFlagSet("offsets reset", flag.ExitOnError)
+	topic := flags.String("topic", cfg.KafkaTopic, "topic to reset")
+	group := flags.String("group", cfg.KafkaGroupID, "consumer group")
+	to := flags.String("to", "", "position for all partitions: earliest, latest, offset or RFC3339 time")
+	partitions := flags.String("partitions", "", "explicit offsets of selected partitions: 0=100,1=250")
+	dryRun := flags.Bool("dry-run", false, "print the plan without committing offsets")
+	flags.Parse(args[1:])
+
+	req := kafka.OffsetReset{
+		Brokers:  cfg.KafkaBrokers,
+		GroupID:  *group,
+		Topic:    *topic,
+		DryRun:   *dryRun,
+		Security: app.KafkaSecurity(cfg),
+	}
+	var err error
+	switch {
+	case (*to == "") == (*partitions == ""):
+		return errors.New(offsetsUsage)
+	case *partitions != "":
+		req.Partitions, err = kafka.ParsePartitionOffsets(*partitions)
+	default:
+		req.To, err = kafka.ParseOffsetSpec(*to)
+	}
+	if err != nil {
+		return err
+	}
 
-	log.Println("Server stopped gracefully")
+	changes, err := kafka.ResetOffsets(ctx, req)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		fmt.Printf("%s/%d: %d -> %d\n", change.Topic, change.Partition, change.From, change.To)
+	}
+	log.Info("consumer offsets reset", "group", *group, "topic", *topic, "partitions", len(changes), "dry_run", *dryRun)
+	return nil
 }
@@ -0,0 +1,8 @@
+package db
+
+import "embed"
+
+// Migrations - SQL-миграции goose, встроенные в бинарник
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE delivery ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS orders_archive(
+    order_uid uuid PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    date_created TIMESTAMP,
+    payload jsonb NOT NULL,
+    archived_at TIMESTAMP NOT NULL default NOW()
+);
+
+CREATE INDEX idx_orders_date_created ON orders USING btree (date_created);
+CREATE INDEX idx_orders_deleted_at ON orders USING btree (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_deleted_at;
+DROP INDEX IF EXISTS idx_orders_date_created;
+DROP TABLE IF EXISTS orders_archive;
+ALTER TABLE delivery DROP COLUMN IF EXISTS erased_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
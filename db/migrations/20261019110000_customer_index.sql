@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_orders_customer_id_date_created ON orders USING btree (customer_id, date_created DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_customer_id_date_created;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_revenue_daily AS
+SELECT date_trunc('day', o.date_created)::date AS day,
+       COALESCE(p.currency, '') AS currency,
+       COALESCE(p.provider, '') AS provider,
+       count(*) AS orders,
+       COALESCE(sum(p.amount), 0)::BIGINT AS revenue,
+       COALESCE(sum(p.delivery_cost), 0)::BIGINT AS delivery_cost
+FROM orders o
+JOIN payments p ON p.id = o.payment_id
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX idx_mv_revenue_daily ON mv_revenue_daily (day, currency, provider);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_item_sales_daily AS
+SELECT date_trunc('day', o.date_created)::date AS day,
+       COALESCE(i.nm_id, 0) AS nm_id,
+       COALESCE(i.brand, '') AS brand,
+       max(i.name) AS name,
+       count(*) AS items,
+       COALESCE(sum(i.total_price), 0)::BIGINT AS revenue,
+       COALESCE(sum(i.sale), 0)::BIGINT AS sale_sum
+FROM orders o
+JOIN items i ON i.id = ANY(o.item_ids)
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX idx_mv_item_sales_daily ON mv_item_sales_daily (day, nm_id, brand);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_delivery_daily AS
+SELECT date_trunc('day', o.date_created)::date AS day,
+       COALESCE(o.delivery_service, '') AS delivery_service,
+       COALESCE(d.region, '') AS region,
+       COALESCE(d.city, '') AS city,
+       count(*) AS orders
+FROM orders o
+JOIN delivery d ON d.id = o.delivery_id
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX idx_mv_delivery_daily ON mv_delivery_daily (day, delivery_service, region, city);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP MATERIALIZED VIEW IF EXISTS mv_delivery_daily;
+DROP MATERIALIZED VIEW IF EXISTS mv_item_sales_daily;
+DROP MATERIALIZED VIEW IF EXISTS mv_revenue_daily;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS order_mismatches(
+    id BIGSERIAL PRIMARY KEY,
+    order_uid uuid NOT NULL,
+    rule TEXT NOT NULL,
+    expected BIGINT NOT NULL,
+    actual BIGINT NOT NULL,
+    source TEXT NOT NULL,
+    detected_at TIMESTAMP NOT NULL default NOW(),
+    UNIQUE (order_uid, rule)
+);
+
+CREATE INDEX idx_order_mismatches_detected_at ON order_mismatches USING btree (detected_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_mismatches;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox(
+    id BIGSERIAL PRIMARY KEY,
+    order_uid uuid NOT NULL,
+    event_type TEXT NOT NULL,
+    payload jsonb NOT NULL,
+    created_at TIMESTAMP NOT NULL default NOW(),
+    published_at TIMESTAMP,
+    attempts INT NOT NULL default 0,
+    last_error TEXT
+);
+
+CREATE INDEX idx_outbox_pending ON outbox USING btree (id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox USING btree (published_at) WHERE published_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL default 'default';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS status TEXT NOT NULL default 'created';
+
+CREATE INDEX idx_orders_tenant ON orders USING btree (tenant);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_tenant;
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
+ALTER TABLE orders DROP COLUMN IF EXISTS tenant;
+-- +goose StatementEnd
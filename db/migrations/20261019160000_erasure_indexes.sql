@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_orders_archive_customer_id ON orders_archive USING btree (customer_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_order_uid ON outbox USING btree (order_uid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_order_uid;
+DROP INDEX IF EXISTS idx_orders_archive_customer_id;
+-- +goose StatementEnd
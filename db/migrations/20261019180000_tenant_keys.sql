@@ -0,0 +1,142 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Заказ определяется витриной и order_uid: один order_uid может встречаться в разных витринах
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_pkey;
+ALTER TABLE orders ADD PRIMARY KEY (tenant, order_uid);
+DROP INDEX IF EXISTS idx_orders_tenant;
+DROP INDEX IF EXISTS idx_orders_customer_id_date_created;
+CREATE INDEX IF NOT EXISTS idx_orders_tenant_customer_id_date_created ON orders USING btree (tenant, customer_id, date_created DESC);
+
+ALTER TABLE orders_archive ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL default 'default';
+ALTER TABLE orders_archive DROP CONSTRAINT IF EXISTS orders_archive_pkey;
+ALTER TABLE orders_archive ADD PRIMARY KEY (tenant, order_uid);
+DROP INDEX IF EXISTS idx_orders_archive_customer_id;
+CREATE INDEX IF NOT EXISTS idx_orders_archive_tenant_customer_id ON orders_archive USING btree (tenant, customer_id);
+
+ALTER TABLE order_mismatches ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL default 'default';
+ALTER TABLE order_mismatches DROP CONSTRAINT IF EXISTS order_mismatches_order_uid_rule_key;
+ALTER TABLE order_mismatches ADD CONSTRAINT order_mismatches_tenant_order_uid_rule_key UNIQUE (tenant, order_uid, rule);
+
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL default 'default';
+DROP INDEX IF EXISTS idx_outbox_order_uid;
+CREATE INDEX IF NOT EXISTS idx_outbox_tenant_order_uid ON outbox USING btree (tenant, order_uid);
+
+-- Витрины аналитики считаются по витринам магазина
+DROP MATERIALIZED VIEW IF EXISTS mv_delivery_daily;
+DROP MATERIALIZED VIEW IF EXISTS mv_item_sales_daily;
+DROP MATERIALIZED VIEW IF EXISTS mv_revenue_daily;
+
+CREATE MATERIALIZED VIEW mv_revenue_daily AS
+SELECT o.tenant,
+       date_trunc('day', o.date_created)::date AS day,
+       COALESCE(p.currency, '') AS currency,
+       COALESCE(p.provider, '') AS provider,
+       count(*) AS orders,
+       COALESCE(sum(p.amount), 0)::BIGINT AS revenue,
+       COALESCE(sum(p.delivery_cost), 0)::BIGINT AS delivery_cost
+FROM orders o
+JOIN payments p ON p.id = o.payment_id
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX idx_mv_revenue_daily ON mv_revenue_daily (tenant, day, currency, provider);
+
+CREATE MATERIALIZED VIEW mv_item_sales_daily AS
+SELECT o.tenant,
+       date_trunc('day', o.date_created)::date AS day,
+       COALESCE(i.nm_id, 0) AS nm_id,
+       COALESCE(i.brand, '') AS brand,
+       max(i.name) AS name,
+       count(*) AS items,
+       COALESCE(sum(i.total_price), 0)::BIGINT AS revenue,
+       COALESCE(sum(i.sale), 0)::BIGINT AS sale_sum
+FROM orders o
+JOIN items i ON i.id = ANY(o.item_ids)
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX idx_mv_item_sales_daily ON mv_item_sales_daily (tenant, day, nm_id, brand);
+
+CREATE MATERIALIZED VIEW mv_delivery_daily AS
+SELECT o.tenant,
+       date_trunc('day', o.date_created)::date AS day,
+       COALESCE(o.delivery_service, '') AS delivery_service,
+       COALESCE(d.region, '') AS region,
+       COALESCE(d.city, '') AS city,
+       count(*) AS orders
+FROM orders o
+JOIN delivery d ON d.id = o.delivery_id
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4, 5;
+
+CREATE UNIQUE INDEX idx_mv_delivery_daily ON mv_delivery_daily (tenant, day, delivery_service, region, city);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP MATERIALIZED VIEW IF EXISTS mv_delivery_daily;
+DROP MATERIALIZED VIEW IF EXISTS mv_item_sales_daily;
+DROP MATERIALIZED VIEW IF EXISTS mv_revenue_daily;
+
+CREATE MATERIALIZED VIEW mv_revenue_daily AS
+SELECT date_trunc('day', o.date_created)::date AS day,
+       COALESCE(p.currency, '') AS currency,
+       COALESCE(p.provider, '') AS provider,
+       count(*) AS orders,
+       COALESCE(sum(p.amount), 0)::BIGINT AS revenue,
+       COALESCE(sum(p.delivery_cost), 0)::BIGINT AS delivery_cost
+FROM orders o
+JOIN payments p ON p.id = o.payment_id
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX idx_mv_revenue_daily ON mv_revenue_daily (day, currency, provider);
+
+CREATE MATERIALIZED VIEW mv_item_sales_daily AS
+SELECT date_trunc('day', o.date_created)::date AS day,
+       COALESCE(i.nm_id, 0) AS nm_id,
+       COALESCE(i.brand, '') AS brand,
+       max(i.name) AS name,
+       count(*) AS items,
+       COALESCE(sum(i.total_price), 0)::BIGINT AS revenue,
+       COALESCE(sum(i.sale), 0)::BIGINT AS sale_sum
+FROM orders o
+JOIN items i ON i.id = ANY(o.item_ids)
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX idx_mv_item_sales_daily ON mv_item_sales_daily (day, nm_id, brand);
+
+CREATE MATERIALIZED VIEW mv_delivery_daily AS
+SELECT date_trunc('day', o.date_created)::date AS day,
+       COALESCE(o.delivery_service, '') AS delivery_service,
+       COALESCE(d.region, '') AS region,
+       COALESCE(d.city, '') AS city,
+       count(*) AS orders
+FROM orders o
+JOIN delivery d ON d.id = o.delivery_id
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX idx_mv_delivery_daily ON mv_delivery_daily (day, delivery_service, region, city);
+
+DROP INDEX IF EXISTS idx_outbox_tenant_order_uid;
+CREATE INDEX IF NOT EXISTS idx_outbox_order_uid ON outbox USING btree (order_uid);
+ALTER TABLE outbox DROP COLUMN IF EXISTS tenant;
+
+ALTER TABLE order_mismatches DROP CONSTRAINT IF EXISTS order_mismatches_tenant_order_uid_rule_key;
+ALTER TABLE order_mismatches DROP COLUMN IF EXISTS tenant;
+ALTER TABLE order_mismatches ADD CONSTRAINT order_mismatches_order_uid_rule_key UNIQUE (order_uid, rule);
+
+DROP INDEX IF EXISTS idx_orders_archive_tenant_customer_id;
+CREATE INDEX IF NOT EXISTS idx_orders_archive_customer_id ON orders_archive USING btree (customer_id);
+ALTER TABLE orders_archive DROP CONSTRAINT IF EXISTS orders_archive_pkey;
+ALTER TABLE orders_archive DROP COLUMN IF EXISTS tenant;
+ALTER TABLE orders_archive ADD PRIMARY KEY (order_uid);
+
+DROP INDEX IF EXISTS idx_orders_tenant_customer_id_date_created;
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id_date_created ON orders USING btree (customer_id, date_created DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_tenant ON orders USING btree (tenant);
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_pkey;
+ALTER TABLE orders ADD PRIMARY KEY (order_uid);
+-- +goose StatementEnd
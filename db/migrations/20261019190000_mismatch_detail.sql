@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE order_mismatches ADD COLUMN IF NOT EXISTS detail TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_mismatches DROP COLUMN IF EXISTS detail;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Выручка товаров в разных валютах не складывается: витрина продаж считается по валютам
+DROP MATERIALIZED VIEW IF EXISTS mv_item_sales_daily;
+
+CREATE MATERIALIZED VIEW mv_item_sales_daily AS
+SELECT o.tenant,
+       date_trunc('day', o.date_created)::date AS day,
+       COALESCE(p.currency, '') AS currency,
+       COALESCE(i.nm_id, 0) AS nm_id,
+       COALESCE(i.brand, '') AS brand,
+       max(i.name) AS name,
+       count(*) AS items,
+       COALESCE(sum(i.total_price), 0)::BIGINT AS revenue,
+       COALESCE(sum(i.sale), 0)::BIGINT AS sale_sum
+FROM orders o
+JOIN payments p ON p.id = o.payment_id
+JOIN items i ON i.id = ANY(o.item_ids)
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4, 5;
+
+CREATE UNIQUE INDEX idx_mv_item_sales_daily ON mv_item_sales_daily (tenant, day, currency, nm_id, brand);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP MATERIALIZED VIEW IF EXISTS mv_item_sales_daily;
+
+CREATE MATERIALIZED VIEW mv_item_sales_daily AS
+SELECT o.tenant,
+       date_trunc('day', o.date_created)::date AS day,
+       COALESCE(i.nm_id, 0) AS nm_id,
+       COALESCE(i.brand, '') AS brand,
+       max(i.name) AS name,
+       count(*) AS items,
+       COALESCE(sum(i.total_price), 0)::BIGINT AS revenue,
+       COALESCE(sum(i.sale), 0)::BIGINT AS sale_sum
+FROM orders o
+JOIN items i ON i.id = ANY(o.item_ids)
+WHERE o.deleted_at IS NULL
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX idx_mv_item_sales_daily ON mv_item_sales_daily (tenant, day, nm_id, brand);
+-- +goose StatementEnd
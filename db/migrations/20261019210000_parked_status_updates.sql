@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Смены статуса, пришедшие раньше заказа: применяются при сохранении заказа
+CREATE TABLE IF NOT EXISTS parked_status_updates(
+    id BIGSERIAL PRIMARY KEY,
+    tenant TEXT NOT NULL,
+    order_uid uuid NOT NULL,
+    status TEXT NOT NULL,
+    reason TEXT,
+    parked_at TIMESTAMP NOT NULL default NOW()
+);
+
+CREATE INDEX idx_parked_status_updates_tenant_order_uid ON parked_status_updates USING btree (tenant, order_uid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS parked_status_updates;
+-- +goose StatementEnd
@@ -0,0 +1,442 @@
+package app
+
+import (
+	"L0WB/internal/config"
+	"L0WB/internal/domain"
+	"L0WB/internal/envelope"
+	"L0WB/internal/exchange"
+	ogen_server "L0WB/internal/generated/servers/http/ordergen"
+	"L0WB/internal/generator"
+	handler "L0WB/internal/handler/http"
+	"L0WB/internal/health"
+	"L0WB/internal/kafka"
+	"L0WB/internal/lifecycle"
+	"L0WB/internal/metrics"
+	"L0WB/internal/repository/analytics"
+	"L0WB/internal/repository/order"
+	"L0WB/internal/repository/outbox"
+	"L0WB/internal/repository/reconciliation"
+	"L0WB/internal/service"
+	"L0WB/internal/storage"
+	"L0WB/internal/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// App собирает компоненты сервиса и управляет их запуском и остановкой.
+// Используется из main и для запуска сервиса внутри интеграционных тестов
+type App struct {
+	cfg config.Config
+	log *slog.Logger
+	lc  *lifecycle.Manager
+
+	pool         *pgxpool.Pool
+	producer     *kafka.OrderProducer
+	consumer     *kafka.OrderConsumer
+	orderCache   *storage.OrderCache
+	orderService *service.Service
+	analytics    *service.AnalyticsService
+	outboxRelay  *service.OutboxRelay
+	warmupGate   *health.Gate
+	server       *http.Server
+
+	mu    sync.Mutex
+	addr  net.Addr
+	ready chan struct{}
+}
+
+// New создает компоненты по конфигурации. Ничего не запускает: соединения
+// и фоновые задачи поднимаются в Run
+func New(ctx context.Context, cfg config.Config, log *slog.Logger) (a *App, err error) {
+	shutdownTimeout := cfg.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = 30 * time.Second
+	}
+
+	a = &App{
+		cfg:   cfg,
+		log:   log,
+		lc:    lifecycle.New(shutdownTimeout, log),
+		ready: make(chan struct{}),
+	}
+
+	if cfg.APIEnabled {
+		if _, err := os.Stat(cfg.WebDir); err != nil {
+			return nil, fmt.Errorf("web directory %q: %w", cfg.WebDir, err)
+		}
+		for _, file := range []string{"index.html", "style.css", "script.js"} {
+			if _, err := os.Stat(filepath.Join(cfg.WebDir, file)); os.IsNotExist(err) {
+				log.Warn("web file not found", "file", filepath.Join(cfg.WebDir, file))
+			}
+		}
+		switch cfg.CacheWarmupOnFailure {
+		case "retry", "open":
+		default:
+			return nil, fmt.Errorf("unknown cache warm-up failure mode %q", cfg.CacheWarmupOnFailure)
+		}
+	}
+
+	var retentionMode domain.RetentionMode
+	if cfg.RetentionDays > 0 {
+		retentionMode, err = domain.ParseRetentionMode(cfg.RetentionMode)
+		if err != nil {
+			return nil, fmt.Errorf("retention config: %w", err)
+		}
+		if cfg.RetentionInterval <= 0 {
+			return nil, fmt.Errorf("retention config: interval must be positive, got %s", cfg.RetentionInterval)
+		}
+	}
+
+	// Без файла курсов пересчет валют в API отключен
+	var rateProvider exchange.RateProvider
+	if cfg.RatesFile != "" {
+		rateProvider, err = exchange.NewFileRateProvider(cfg.RatesFile)
+		if err != nil {
+			return nil, fmt.Errorf("exchange rates: %w", err)
+		}
+	}
+
+	meterProvider, err := metrics.NewMeterProvider()
+	if err != nil {
+		return nil, fmt.Errorf("metrics: %w", err)
+	}
+	a.lc.Append(lifecycle.Hook{Name: "meter-provider", OnStop: meterProvider.Shutdown})
+
+	tracerProvider, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName:  "order-service",
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		SampleRatio:  cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+	a.lc.Append(lifecycle.Hook{Name: "tracer-provider", OnStop: tracerProvider.Shutdown})
+
+	poolConfig, err := pgxpool.ParseConfig(cfg.PgDSN)
+	if err != nil {
+		return nil, fmt.Errorf("database config: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = tracing.NewPgxTracer()
+
+	a.pool, err = pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("database connection: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			a.pool.Close()
+		}
+	}()
+	a.lc.Append(lifecycle.Hook{
+		Name: "postgres",
+		OnStop: func(ctx context.Context) error {
+			a.pool.Close()
+			return nil
+		},
+	})
+
+	kafkaSecurity, err := kafka.NewSecurity(KafkaSecurity(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("kafka security: %w", err)
+	}
+	if err := a.appendKafkaCheck(kafkaSecurity); err != nil {
+		return nil, err
+	}
+
+	a.producer, err = kafka.NewOrderProducer(ProducerConfig(cfg), log)
+	if err != nil {
+		return nil, fmt.Errorf("producer: %w", err)
+	}
+	a.lc.Append(lifecycle.Hook{
+		Name: "producer",
+		OnStop: func(ctx context.Context) error {
+			return a.producer.Close()
+		},
+	})
+
+	eventProducer, err := kafka.NewEventProducer(EventProducerConfig(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("event producer: %w", err)
+	}
+	a.lc.Append(lifecycle.Hook{
+		Name: "event-producer",
+		OnStop: func(ctx context.Context) error {
+			return eventProducer.Close()
+		},
+	})
+
+	// Генератор тестовых заказов пишет в рабочий топик, поэтому собирается только для локального запуска
+	var orderGenerator service.OrderGenerator
+	if cfg.GeneratorEnabled {
+		orderGenerator, err = generator.FromSettings(GeneratorSettings(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("order generator: %w", err)
+		}
+	}
+
+	// Кеш заказов с TTL 1 час
+	a.orderCache = storage.NewOrderCache(1 * time.Hour)
+	summaryCache := storage.NewCustomerSummaryCache(5 * time.Minute)
+	metrics.RegisterCacheSize("orders", a.orderCache.Size)
+
+	reconciliationService := service.NewReconciliationService(reconciliation.NewRepository(a.pool), log)
+	a.orderService = service.NewService(order.NewRepository(a.pool), a.orderCache, summaryCache, reconciliationService, orderGenerator, a.producer, log)
+	a.analytics = service.NewAnalyticsService(analytics.NewRepository(a.pool), log)
+	a.outboxRelay = service.NewOutboxRelay(outbox.NewRepository(a.pool), eventProducer, cfg.OutboxBatchSize, cfg.OutboxRetention, log)
+
+	//Служебные операции консьюмера обслуживает HTTP API, поэтому он создается раньше обработчиков
+	var consumerAdmin handler.IConsumerAdmin
+	if cfg.ConsumerEnabled {
+		registry, err := envelope.LoadRegistry(cfg.SchemaRegistryDir)
+		if err != nil {
+			return nil, fmt.Errorf("schema registry: %w", err)
+		}
+		codecs, err := envelope.NewCodecs(registry)
+		if err != nil {
+			return nil, fmt.Errorf("codecs: %w", err)
+		}
+
+		//Выключатель consumer следит за занятостью пула соединений
+		consumerCfg := ConsumerConfig(cfg)
+		consumerCfg.Flow.PoolUsage = func() float64 {
+			stat := a.pool.Stat()
+			return float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+		}
+		a.consumer, err = kafka.NewOrderConsumer(ctx, consumerCfg, a.orderService, codecs, log)
+		if err != nil {
+			return nil, fmt.Errorf("consumer: %w", err)
+		}
+		consumerAdmin = a.consumer
+	}
+
+	api := handler.NewHandler(a.orderService, a.analytics, reconciliationService, exchange.NewConverter(rateProvider), consumerAdmin)
+	srv, err := ogen_server.NewServer(api,
+		ogen_server.WithMeterProvider(meterProvider),
+		ogen_server.WithTracerProvider(tracerProvider),
+		ogen_server.WithErrorHandler(handler.ErrorHandler),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("server creation: %w", err)
+	}
+
+	// Проверки готовности: до окончания прогрева кеша API не готово принимать трафик
+	healthChecker := health.NewChecker(cfg.HealthCheckTimeout)
+	healthChecker.Register("postgres", a.pool.Ping)
+	healthChecker.Register("kafka", func(ctx context.Context) error {
+		return kafka.PingBrokers(ctx, cfg.KafkaBrokers, kafkaSecurity)
+	})
+	if cfg.APIEnabled {
+		a.warmupGate = health.NewGate("cache warm-up in progress")
+		healthChecker.Register("cache", a.warmupGate.Check)
+	}
+
+	a.server = &http.Server{
+		Addr:    cfg.ServerPort,
+		Handler: handler.RequestLogging(log, a.routes(srv, healthChecker)),
+	}
+	a.lc.Append(lifecycle.Hook{
+		Name:    "http",
+		OnStart: a.startServer,
+		OnStop:  a.server.Shutdown,
+	})
+
+	a.appendWorkers(retentionMode)
+
+	if a.consumer != nil {
+		consumerWorker := lifecycle.NewWorker(a.consumer.Consume)
+		a.lc.Append(lifecycle.Hook{
+			Name:    "consumer",
+			OnStart: consumerWorker.Start,
+			OnStop: func(ctx context.Context) error {
+				return errors.Join(consumerWorker.Stop(ctx), a.consumer.Close())
+			},
+		})
+	}
+
+	a.lc.Append(lifecycle.Hook{
+		Name: "ready",
+		OnStart: func(ctx context.Context) error {
+			close(a.ready)
+			return nil
+		},
+	})
+
+	return a, nil
+}
+
+// appendKafkaCheck проверяет при старте подключение к Kafka с настроенными TLS и SASL.
+// В режиме warn ошибка только пишется в лог, в режиме fail останавливает запуск
+func (a *App) appendKafkaCheck(security *kafka.Security) error {
+	mode := a.cfg.KafkaStartupCheck
+	switch mode {
+	case "off":
+		return nil
+	case "warn", "fail", "":
+	default:
+		return fmt.Errorf("unknown kafka startup check mode %q", mode)
+	}
+
+	topics := []string{a.cfg.KafkaTopic}
+	if a.cfg.ConsumerEnabled {
+		topics = append(topics, a.cfg.KafkaConsumerTopics...)
+	}
+	if a.cfg.OutboxRelayInterval > 0 {
+		topics = append(topics, a.cfg.OutboxTopic)
+	}
+
+	a.lc.Append(lifecycle.Hook{
+		Name: "kafka-check",
+		OnStart: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			defer cancel()
+
+			if err := kafka.CheckConnectivity(ctx, a.cfg.KafkaBrokers, topics, security); err != nil {
+				if mode == "fail" {
+					return fmt.Errorf("kafka startup check: %w", err)
+				}
+				a.log.Warn("kafka startup check failed", "security", security.Description(), "error", err)
+				return nil
+			}
+			a.log.Info("kafka startup check passed", "security", security.Description(), "topics", topics)
+			return nil
+		},
+	})
+	return nil
+}
+
+// appendWorkers регистрирует фоновые задачи. Они останавливаются раньше HTTP-сервера и пула
+func (a *App) appendWorkers(retentionMode domain.RetentionMode) {
+	// Прогрев кеша идет в фоне, /readyz отвечает 503 до его окончания
+	if a.cfg.APIEnabled {
+		a.lc.Append(lifecycle.NewWorker(a.warmUpCache).Hook("cache-warmup"))
+	}
+
+	// Периодическое обновление материализованных представлений аналитики
+	if a.cfg.AnalyticsRefreshInterval > 0 {
+		a.lc.Append(lifecycle.NewWorker(func(ctx context.Context) {
+			a.analytics.RunRefresh(ctx, a.cfg.AnalyticsRefreshInterval)
+		}).Hook("analytics-refresh"))
+	}
+
+	// Фоновое применение политики хранения
+	if a.cfg.RetentionDays > 0 {
+		policy := domain.RetentionPolicy{
+			OlderThan: time.Duration(a.cfg.RetentionDays) * 24 * time.Hour,
+			Mode:      retentionMode,
+		}
+		a.lc.Append(lifecycle.NewWorker(func(ctx context.Context) {
+			a.orderService.RunRetention(ctx, policy, a.cfg.RetentionInterval)
+		}).Hook("retention"))
+	}
+
+	// Публикация событий из outbox; реплики разбирают таблицу параллельно
+	if a.cfg.OutboxRelayInterval > 0 {
+		cleanupInterval := a.cfg.OutboxCleanupInterval
+		if cleanupInterval <= 0 {
+			cleanupInterval = time.Hour
+		}
+		a.lc.Append(lifecycle.NewWorker(func(ctx context.Context) {
+			a.outboxRelay.Run(ctx, a.cfg.OutboxRelayInterval, cleanupInterval)
+		}).Hook("outbox-relay"))
+	}
+
+	if a.cfg.GeneratorEnabled && a.cfg.AutoGenerate {
+		a.lc.Append(lifecycle.NewWorker(a.autoGenerate).Hook("auto-generate"))
+	}
+}
+
+func (a *App) startServer(ctx context.Context) error {
+	listener, err := net.Listen("tcp", a.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.addr = listener.Addr()
+	a.mu.Unlock()
+
+	a.log.Info("Server starting", "addr", listener.Addr().String())
+	go func() {
+		if err := a.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.lc.Abort(fmt.Errorf("http server: %w", err))
+		}
+	}()
+	return nil
+}
+
+// warmUpCache открывает /readyz только после успешного прогрева; при ошибке действует
+// CACHE_WARMUP_ON_FAILURE
+func (a *App) warmUpCache(ctx context.Context) {
+	for {
+		err := a.tryWarmUpCache(ctx)
+		if err == nil {
+			a.log.Info("WarmUpCache finished", "size", a.orderCache.Size())
+			a.warmupGate.Open()
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		//В режиме open трафик идет в БД мимо холодного кеша, в режиме retry экземпляр остается неготовым
+		if a.cfg.CacheWarmupOnFailure == "open" {
+			a.log.Warn("WarmUpCache failed, serving with cold cache", "error", err)
+			a.warmupGate.Open()
+			return
+		}
+		a.log.Error("WarmUpCache failed, retrying", "error", err, "retry_in", a.cfg.CacheWarmupRetryInterval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(a.cfg.CacheWarmupRetryInterval):
+		}
+	}
+}
+
+func (a *App) tryWarmUpCache(ctx context.Context) error {
+	warmupCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	return a.orderService.WarmUpCache(warmupCtx)
+}
+
+// autoGenerate отправляет тестовый заказ через 3 секунды после старта
+func (a *App) autoGenerate(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(3 * time.Second):
+	}
+
+	a.log.Info("Auto-generating test order")
+	if _, err := a.orderService.GenerateFakeOrdersFromKafka(ctx, "", 1, 0); err != nil {
+		a.log.Error("Error auto-generating order", "error", err)
+	}
+}
+
+// Run запускает компоненты и блокируется до отмены ctx, после чего останавливает их
+// в обратном порядке: consumer, фоновые задачи, HTTP-сервер, producer, пул соединений
+func (a *App) Run(ctx context.Context) error {
+	return a.lc.Run(ctx)
+}
+
+// Ready закрывается, когда все компоненты запущены
+func (a *App) Ready() <-chan struct{} {
+	return a.ready
+}
+
+// Addr возвращает адрес HTTP-сервера после запуска (полезно при SERVER_PORT=":0")
+func (a *App) Addr() net.Addr {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.addr
+}
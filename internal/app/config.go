@@ -0,0 +1,92 @@
+package app
+
+import (
+	"L0WB/internal/config"
+	"L0WB/internal/generator"
+	"L0WB/internal/kafka"
+)
+
+// Переменные окружения переводятся в настройки компонентов здесь: config остается набором значений
+
+// GeneratorSettings - настройки генератора тестовых заказов
+func GeneratorSettings(c config.Config) generator.Settings {
+	return generator.Settings{
+		Strategy:     c.GeneratorStrategy,
+		Seed:         c.GeneratorSeed,
+		Dictionaries: c.GeneratorDictionaries,
+		InvalidRate:  c.GeneratorInvalidRate,
+		ReplayFile:   c.GeneratorReplayFile,
+		TemplateFile: c.GeneratorTemplateFile,
+	}
+}
+
+func ProducerConfig(c config.Config) kafka.ProducerConfig {
+	return kafka.ProducerConfig{
+		Brokers:           c.KafkaBrokers,
+		Topic:             c.KafkaTopic,
+		KeyField:          c.KafkaMessageKey,
+		ProducerID:        c.KafkaProducerID,
+		Security:          KafkaSecurity(c),
+		Codec:             c.KafkaCodec,
+		SchemaVersion:     c.KafkaSchemaVersion,
+		SchemaRegistryDir: c.SchemaRegistryDir,
+		BatchSize:         c.KafkaBatchSize,
+		BatchTimeout:      c.KafkaBatchTimeout,
+		Async:             c.KafkaAsync,
+		RequiredAcks:      c.KafkaRequiredAcks,
+		Compression:       c.KafkaCompression,
+	}
+}
+
+// EventProducerConfig - настройки продюсера событий из outbox
+func EventProducerConfig(c config.Config) kafka.ProducerConfig {
+	return kafka.ProducerConfig{
+		Brokers:      c.KafkaBrokers,
+		Topic:        c.OutboxTopic,
+		ProducerID:   c.KafkaProducerID,
+		Security:     KafkaSecurity(c),
+		BatchSize:    c.OutboxBatchSize,
+		BatchTimeout: c.KafkaBatchTimeout,
+		Compression:  c.KafkaCompression,
+	}
+}
+
+func KafkaSecurity(c config.Config) kafka.SecurityConfig {
+	return kafka.SecurityConfig{
+		TLS:                c.KafkaTLSEnabled,
+		CAFile:             c.KafkaTLSCAFile,
+		CertFile:           c.KafkaTLSCertFile,
+		KeyFile:            c.KafkaTLSKeyFile,
+		ServerName:         c.KafkaTLSServerName,
+		InsecureSkipVerify: c.KafkaTLSInsecureSkipVerify,
+		SASLMechanism:      c.KafkaSASLMechanism,
+		Username:           c.KafkaSASLUsername,
+		Password:           c.KafkaSASLPassword,
+	}
+}
+
+func ConsumerConfig(c config.Config) kafka.ConsumerConfig {
+	topics := c.KafkaConsumerTopics
+	if len(topics) == 0 && c.KafkaConsumerTopicPattern == "" {
+		topics = []string{c.KafkaTopic}
+	}
+	return kafka.ConsumerConfig{
+		Brokers:      c.KafkaBrokers,
+		Topics:       topics,
+		TopicPattern: c.KafkaConsumerTopicPattern,
+		GroupID:      c.KafkaGroupID,
+		ClientID:     c.KafkaClientID,
+		Routes:       c.KafkaTopicRoutes,
+		TopicTenants: c.KafkaTopicTenants,
+		Flow: kafka.FlowConfig{
+			MaxRate:        c.KafkaConsumerMaxRate,
+			Burst:          c.KafkaConsumerBurst,
+			PoolSaturation: c.KafkaConsumerBreakerPoolUsage,
+			ErrorRate:      c.KafkaConsumerBreakerErrorRate,
+			ErrorWindow:    c.KafkaConsumerBreakerWindow,
+			MinMessages:    c.KafkaConsumerBreakerMinMessages,
+			Cooldown:       c.KafkaConsumerBreakerCooldown,
+		},
+		Security: KafkaSecurity(c),
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"L0WB/internal/domain"
+	ogen_server "L0WB/internal/generated/servers/http/ordergen"
+	"L0WB/internal/health"
+	"encoding/json"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// routes собирает HTTP-маршруты. Служебные /metrics, /healthz и /readyz доступны всегда,
+// управление консьюмером - на экземплярах с консьюмером, API и веб-интерфейс - только при включенном API
+func (a *App) routes(srv *ogen_server.Server, healthChecker *health.Checker) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/healthz", health.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
+	if a.cfg.ConsumerEnabled {
+		mux.Handle("/admin/consumer", srv)
+		mux.Handle("/admin/consumer/replay", srv)
+		mux.Handle("/admin/consumer/pause", srv)
+		mux.Handle("/admin/consumer/resume", srv)
+		mux.Handle("/admin/consumer/rate", srv)
+	}
+
+	if !a.cfg.APIEnabled {
+		return mux
+	}
+
+	webDir := a.cfg.WebDir
+	fileServer := http.FileServer(http.Dir(webDir))
+
+	// Обработка статических файлов
+	mux.Handle("/web/", http.StripPrefix("/web/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".js") {
+			w.Header().Set("Content-Type", "application/javascript")
+		} else if strings.HasSuffix(r.URL.Path, ".css") {
+			w.Header().Set("Content-Type", "text/css")
+		}
+		fileServer.ServeHTTP(w, r)
+	})))
+
+	// API endpoint для получения заказа по ID
+	mux.HandleFunc("/order/get-order/", func(w http.ResponseWriter, r *http.Request) {
+		// CORS headers
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != "GET" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Извлекаем UUID из URL: /order/get-order/{uuid}
+		pathParts := strings.Split(r.URL.Path, "/")
+		if len(pathParts) < 4 {
+			http.Error(w, "Invalid URL", http.StatusBadRequest)
+			return
+		}
+
+		orderUID := pathParts[3]
+		a.log.DebugContext(r.Context(), "GET order request", "order_uid", orderUID)
+
+		id, _, err := domain.ParseOrderUID(orderUID)
+		if err != nil {
+			http.Error(w, "Invalid order UUID", http.StatusBadRequest)
+			return
+		}
+		tenant, err := domain.ParseTenant(r.Header.Get("X-Tenant"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		order, err := a.orderService.GetOrder(r.Context(), domain.OrderKey{Tenant: tenant, OrderUID: id})
+		if err != nil {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    order,
+			"cached":  false,
+		})
+	})
+
+	if a.cfg.GeneratorEnabled {
+		mux.Handle("/generate", srv)
+	}
+	mux.Handle("/order/", srv)
+	mux.Handle("/customer/", srv)
+	mux.Handle("/customers/", srv)
+	mux.Handle("/admin/", srv)
+	mux.Handle("/analytics/", srv)
+	mux.Handle("/reconciliation/", srv)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// Если запрос к статическим файлам
+		if strings.HasPrefix(r.URL.Path, "/web/") {
+			fileServer.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/order/get-order/") ||
+			strings.HasPrefix(r.URL.Path, "/order/order/") {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		http.ServeFile(w, r, filepath.Join(webDir, "index.html"))
+	})
+
+	return mux
+}
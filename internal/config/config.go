@@ -1,8 +1,130 @@
 package config
 
+import (
+	"fmt"
+	"github.com/kelseyhightower/envconfig"
+	"time"
+)
+
 type Config struct {
-	PgDSN        string `envconfig:"PG_DSN"`
-	KafkaBrokers string `envconfig:"KAFKA_BROKERS"`
-	KafkaTopic   string `envconfig:"KAFKA_TOPIC"`
-	ServerPort   string `envconfig:"SERVER_PORT"`
+	PgDSN        string   `envconfig:"PG_DSN"`
+	KafkaBrokers []string `envconfig:"KAFKA_BROKERS" default:"localhost:9092"`
+	KafkaTopic   string   `envconfig:"KAFKA_TOPIC" default:"orders"`
+	ServerPort   string   `envconfig:"SERVER_PORT" default:":8081"`
+
+	// Ключ сообщений Kafka: order_uid, customer_id или shard_key; producer_id по умолчанию - имя хоста
+	KafkaMessageKey string `envconfig:"KAFKA_MESSAGE_KEY" default:"order_uid"`
+	KafkaProducerID string `envconfig:"KAFKA_PRODUCER_ID"`
+
+	// Consumer: топики списком и/или регулярным выражением (по умолчанию KAFKA_TOPIC), правила
+	// "<regexp>=<handler>" для обработчиков status и cancellation, витрины по топикам "topic:tenant"
+	KafkaConsumerTopics       []string          `envconfig:"KAFKA_CONSUMER_TOPICS"`
+	KafkaConsumerTopicPattern string            `envconfig:"KAFKA_CONSUMER_TOPIC_PATTERN"`
+	KafkaGroupID              string            `envconfig:"KAFKA_GROUP_ID" default:"order-service-group"`
+	KafkaClientID             string            `envconfig:"KAFKA_CLIENT_ID"`
+	KafkaTopicRoutes          []string          `envconfig:"KAFKA_TOPIC_ROUTES"`
+	KafkaTopicTenants         map[string]string `envconfig:"KAFKA_TOPIC_TENANTS"`
+
+	// Ограничение приема: сообщений в секунду (0 - без ограничения) и выключатель, который ставит
+	// consumer на паузу при занятости пула БД или доле ошибок сохранения за окно (0 отключает проверку)
+	KafkaConsumerMaxRate            float64       `envconfig:"KAFKA_CONSUMER_MAX_RATE"`
+	KafkaConsumerBurst              int           `envconfig:"KAFKA_CONSUMER_BURST" default:"10"`
+	KafkaConsumerBreakerPoolUsage   float64       `envconfig:"KAFKA_CONSUMER_BREAKER_POOL_USAGE" default:"0.9"`
+	KafkaConsumerBreakerErrorRate   float64       `envconfig:"KAFKA_CONSUMER_BREAKER_ERROR_RATE" default:"0.5"`
+	KafkaConsumerBreakerWindow      time.Duration `envconfig:"KAFKA_CONSUMER_BREAKER_WINDOW" default:"1m"`
+	KafkaConsumerBreakerMinMessages int           `envconfig:"KAFKA_CONSUMER_BREAKER_MIN_MESSAGES" default:"20"`
+	KafkaConsumerBreakerCooldown    time.Duration `envconfig:"KAFKA_CONSUMER_BREAKER_COOLDOWN" default:"30s"`
+
+	// Защищенное подключение к Kafka: TLS (CA, клиентский сертификат для mTLS) и SASL plain,
+	// scram-sha-256 или scram-sha-512. Проверка подключения при старте: warn, fail или off
+	KafkaTLSEnabled            bool   `envconfig:"KAFKA_TLS_ENABLED"`
+	KafkaTLSCAFile             string `envconfig:"KAFKA_TLS_CA_FILE"`
+	KafkaTLSCertFile           string `envconfig:"KAFKA_TLS_CERT_FILE"`
+	KafkaTLSKeyFile            string `envconfig:"KAFKA_TLS_KEY_FILE"`
+	KafkaTLSServerName         string `envconfig:"KAFKA_TLS_SERVER_NAME"`
+	KafkaTLSInsecureSkipVerify bool   `envconfig:"KAFKA_TLS_INSECURE_SKIP_VERIFY"`
+	KafkaSASLMechanism         string `envconfig:"KAFKA_SASL_MECHANISM"`
+	KafkaSASLUsername          string `envconfig:"KAFKA_SASL_USERNAME"`
+	KafkaSASLPassword          string `envconfig:"KAFKA_SASL_PASSWORD"`
+	KafkaStartupCheck          string `envconfig:"KAFKA_STARTUP_CHECK" default:"warn"`
+
+	// Формат сообщений с заказами: кодек json/protobuf/avro и версия схемы заказа 1 или 2.
+	// Consumer читает любые кодеки и версии; каталог дополняет встроенные схемы Avro
+	KafkaCodec         string `envconfig:"KAFKA_CODEC" default:"json"`
+	KafkaSchemaVersion int    `envconfig:"KAFKA_SCHEMA_VERSION" default:"1"`
+	SchemaRegistryDir  string `envconfig:"SCHEMA_REGISTRY_DIR"`
+
+	// Продюсер: батч до KAFKA_BATCH_SIZE сообщений или KAFKA_BATCH_TIMEOUT ожидания,
+	// acks none/one/all, сжатие none/gzip/snappy/lz4/zstd
+	KafkaBatchSize    int           `envconfig:"KAFKA_BATCH_SIZE" default:"100"`
+	KafkaBatchTimeout time.Duration `envconfig:"KAFKA_BATCH_TIMEOUT" default:"10ms"`
+	KafkaAsync        bool          `envconfig:"KAFKA_ASYNC"`
+	KafkaRequiredAcks string        `envconfig:"KAFKA_REQUIRED_ACKS" default:"all"`
+	KafkaCompression  string        `envconfig:"KAFKA_COMPRESSION" default:"none"`
+
+	// Outbox: события заказов публикуются в OUTBOX_TOPIC раз в OUTBOX_RELAY_INTERVAL (0 отключает relay),
+	// опубликованные события хранятся OUTBOX_RETENTION
+	OutboxTopic           string        `envconfig:"OUTBOX_TOPIC" default:"order-events"`
+	OutboxRelayInterval   time.Duration `envconfig:"OUTBOX_RELAY_INTERVAL" default:"1s"`
+	OutboxBatchSize       int           `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+	OutboxRetention       time.Duration `envconfig:"OUTBOX_RETENTION" default:"24h"`
+	OutboxCleanupInterval time.Duration `envconfig:"OUTBOX_CLEANUP_INTERVAL" default:"1h"`
+
+	// Компоненты процесса: можно поднять реплику только с API или только с consumer.
+	// HTTP-сервер с /metrics, /healthz и /readyz работает всегда
+	APIEnabled      bool `envconfig:"API_ENABLED" default:"true"`
+	ConsumerEnabled bool `envconfig:"CONSUMER_ENABLED" default:"true"`
+	// Генератор тестовых заказов и POST /generate, только для локального запуска (команда all);
+	// AUTO_GENERATE дополнительно отправляет тестовый заказ через 3 секунды после старта
+	GeneratorEnabled bool `envconfig:"GENERATOR_ENABLED"`
+	AutoGenerate     bool `envconfig:"AUTO_GENERATE"`
+
+	WebDir string `envconfig:"WEB_DIR" default:"./web"`
+
+	// Генератор тестовых заказов: стратегия random, replay или template; seed 0 берется от часов,
+	// без файла - справочники по умолчанию. replay и template доступны, только если задан их файл
+	GeneratorStrategy     string  `envconfig:"GENERATOR_STRATEGY" default:"random"`
+	GeneratorSeed         int64   `envconfig:"GENERATOR_SEED"`
+	GeneratorDictionaries string  `envconfig:"GENERATOR_DICTIONARIES"`
+	GeneratorInvalidRate  float64 `envconfig:"GENERATOR_INVALID_RATE"`
+	GeneratorReplayFile   string  `envconfig:"GENERATOR_REPLAY_FILE"`
+	GeneratorTemplateFile string  `envconfig:"GENERATOR_TEMPLATE_FILE"`
+
+	// Логирование: уровень debug/info/warn/error, формат text/json
+	LogLevel  string `envconfig:"LOG_LEVEL" default:"info"`
+	LogFormat string `envconfig:"LOG_FORMAT" default:"text"`
+
+	HealthCheckTimeout time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+
+	// Неудачный прогрев кеша: retry оставляет /readyz в 503 и повторяет прогрев через
+	// CACHE_WARMUP_RETRY_INTERVAL, open открывает трафик с холодным кешем
+	CacheWarmupOnFailure     string        `envconfig:"CACHE_WARMUP_ON_FAILURE" default:"retry"`
+	CacheWarmupRetryInterval time.Duration `envconfig:"CACHE_WARMUP_RETRY_INTERVAL" default:"10s"`
+
+	// Общий дедлайн остановки всех компонентов после SIGINT/SIGTERM
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
+
+	// Хранение заказов: 0 дней отключает фоновую очистку
+	RetentionDays     int           `envconfig:"RETENTION_DAYS"`
+	RetentionMode     string        `envconfig:"RETENTION_MODE" default:"archive"`
+	RetentionInterval time.Duration `envconfig:"RETENTION_INTERVAL" default:"1h"`
+
+	// Файл с курсами валют для пересчета сумм в ответах API
+	RatesFile string `envconfig:"RATES_FILE" default:"./data/rates.json"`
+
+	AnalyticsRefreshInterval time.Duration `envconfig:"ANALYTICS_REFRESH_INTERVAL" default:"5m"`
+
+	// Трассировка: none, stdout или otlp
+	TracingExporter     string  `envconfig:"TRACING_EXPORTER" default:"none"`
+	TracingOTLPEndpoint string  `envconfig:"TRACING_OTLP_ENDPOINT"`
+	TracingSampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+}
+
+// Load читает конфигурацию из переменных окружения
+func Load() (Config, error) {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		return Config{}, fmt.Errorf("error loading config: %v", err)
+	}
+	return cfg, nil
 }
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+type RevenuePoint struct {
+	Day          time.Time
+	Currency     string
+	Provider     string
+	Orders       int64
+	Revenue      int64
+	DeliveryCost int64
+}
+
+type BrandSales struct {
+	Currency string
+	Brand    string
+	Items    int64
+	Revenue  int64
+}
+
+type ItemSales struct {
+	Currency string
+	NmID     int64
+	Name     string
+	Brand    string
+	Items    int64
+	Revenue  int64
+}
+
+type DiscountStats struct {
+	Items       int64
+	AverageSale float64
+}
+
+type DeliveryServiceShare struct {
+	DeliveryService string
+	Orders          int64
+	Share           float64
+}
+
+type RegionOrders struct {
+	Region string
+	City   string
+	Orders int64
+}
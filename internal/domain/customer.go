@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type CurrencySpend struct {
+	Currency string
+	Total    int64
+	Orders   int
+}
+
+type BrandCount struct {
+	Brand string
+	Items int
+}
+
+type CustomerSummary struct {
+	Tenant          string
+	CustomerID      string
+	OrdersCount     int
+	SpendByCurrency []CurrencySpend
+	FirstOrderAt    time.Time
+	LastOrderAt     time.Time
+	FavouriteBrands []BrandCount
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+var (
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrInvalidTenant    = errors.New("invalid tenant")
+	// Генератор тестовых заказов собирается только для локального запуска
+	ErrGeneratorDisabled = errors.New("order generator is disabled")
+	// Управление консьюмером доступно только на экземплярах с consumer
+	ErrConsumerDisabled = errors.New("consumer is disabled")
+)
@@ -0,0 +1,42 @@
+package domain
+
+import "log/slog"
+
+const redacted = "[REDACTED]"
+
+// LogValue скрывает персональные данные доставки при логировании через slog
+func (d Delivery) LogValue() slog.Value {
+	return deliveryLogValue(d.Name, d.Phone, d.Email, d.Address, d.Zip, d.City, d.Region)
+}
+
+func (d FakeDelivery) LogValue() slog.Value {
+	return deliveryLogValue(d.Name, d.Phone, d.Email, d.Address, d.Zip, d.City, d.Region)
+}
+
+func deliveryLogValue(name, phone, email, address, zip, city, region string) slog.Value {
+	hide := func(v string) string {
+		if v == "" {
+			return ""
+		}
+		return redacted
+	}
+	return slog.GroupValue(
+		slog.String("name", hide(name)),
+		slog.String("phone", hide(phone)),
+		slog.String("email", hide(email)),
+		slog.String("address", hide(address)),
+		slog.String("zip", zip),
+		slog.String("city", city),
+		slog.String("region", region),
+	)
+}
+
+func (o Order) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("order_uid", o.ID.String()),
+		slog.String("track_number", o.TrackNumber),
+		slog.String("customer_id", o.CustumerID),
+		slog.Any("delivery", o.Delivery),
+		slog.Int("items", len(o.Items)),
+	)
+}
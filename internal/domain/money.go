@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/text/currency"
+	"math"
+	"strings"
+)
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Currency - валюта ISO-4217 с количеством знаков минимальной единицы
+type Currency struct {
+	Code       string
+	MinorUnits int
+}
+
+// Коды ISO-4217, которые не обозначают денежную валюту
+var nonMonetary = map[string]bool{
+	"XXX": true, // без валюты
+	"XTS": true, // для тестирования
+}
+
+// unknownMinorUnits - знаки минимальной единицы, с которыми хранятся суммы в неизвестной валюте
+const unknownMinorUnits = 2
+
+// ParseCurrency находит валюту в таблице ISO-4217 (golang.org/x/text/currency)
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	unit, err := currency.ParseISO(code)
+	if err != nil || nonMonetary[code] {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	return Currency{Code: unit.String(), MinorUnits: scale}, nil
+}
+
+// CurrencyOf возвращает валюту заказа по коду. Неизвестный код сохраняется как есть:
+// заказ принимается, а валюта попадает в отчет сверки
+func CurrencyOf(code string) Currency {
+	if c, err := ParseCurrency(code); err == nil {
+		return c
+	}
+	return Currency{Code: strings.ToUpper(strings.TrimSpace(code)), MinorUnits: unknownMinorUnits}
+}
+
+// Known - валюта есть в таблице ISO-4217
+func (c Currency) Known() bool {
+	_, err := ParseCurrency(c.Code)
+	return err == nil
+}
+
+// Money - сумма в минимальных единицах валюты (центы, копейки)
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(amount int64, code string) (Money, error) {
+	c, err := ParseCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: c}, nil
+}
+
+// Convert пересчитывает сумму по курсу rate (единиц to за одну единицу исходной валюты)
+// с учетом разного количества знаков минимальной единицы и округлением до ближайшего
+func (m Money) Convert(to Currency, rate float64) Money {
+	major := float64(m.Amount) / math.Pow10(m.Currency.MinorUnits)
+	return Money{
+		Amount:   int64(math.Round(major * rate * math.Pow10(to.MinorUnits))),
+		Currency: to,
+	}
+}
+
+func (m Money) String() string {
+	if m.Currency.MinorUnits == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency.Code)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	div := int64(math.Pow10(m.Currency.MinorUnits))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/div, m.Currency.MinorUnits, amount%div, m.Currency.Code)
+}
+
+// Conversion описывает пересчет сумм заказа в другую валюту
+type Conversion struct {
+	From Currency
+	To   Currency
+	Rate float64
+}
+
+// ConvertOrder возвращает копию заказа с денежными полями, пересчитанными по курсу
+func ConvertOrder(order Order, conv Conversion) Order {
+	convert := func(m Money) Money {
+		return Money{Amount: m.Amount, Currency: conv.From}.Convert(conv.To, conv.Rate)
+	}
+
+	res := order
+	res.Payment.Amount = convert(order.Payment.Amount)
+	res.Payment.DeliveryCost = convert(order.Payment.DeliveryCost)
+	res.Payment.GoodsTotal = convert(order.Payment.GoodsTotal)
+	res.Payment.CustomFee = convert(order.Payment.CustomFee)
+
+	res.Items = make([]Item, len(order.Items))
+	for i, item := range order.Items {
+		item.Price = convert(item.Price)
+		item.TotalPrice = convert(item.TotalPrice)
+		res.Items[i] = item
+	}
+	return res
+}
@@ -7,6 +7,8 @@ import (
 
 type Order struct {
 	ID                uuid.UUID
+	Tenant            string
+	Status            string
 	TrackNumber       string
 	Entry             string
 	Delivery          Delivery
@@ -20,6 +22,16 @@ type Order struct {
 	SmID              int
 	DateCreated       time.Time
 	OofShard          string
+	// Исходный order_uid, если он не UUID и ID получен из него через ParseOrderUID
+	SourceOrderUID string
+}
+
+func (o *Order) Key() OrderKey {
+	return OrderKey{Tenant: o.Tenant, OrderUID: o.ID}
+}
+
+func (o *Order) Customer() CustomerKey {
+	return CustomerKey{Tenant: o.Tenant, CustomerID: o.CustumerID}
 }
 
 type Delivery struct {
@@ -32,28 +44,33 @@ type Delivery struct {
 	Email   string
 }
 
+// Payment - платеж заказа; все суммы заказа, включая цены товаров, в одной валюте
 type Payment struct {
 	Transaction  string
 	RequestID    string
-	Currency     string
 	Provider     string
-	Amount       int
+	Amount       Money
 	PaymentDt    int
 	Bank         string
-	DeliveryCost int
-	GoodsTotal   int
-	CustomFee    int
+	DeliveryCost Money
+	GoodsTotal   Money
+	CustomFee    Money
+}
+
+// Currency - валюта платежа
+func (p Payment) Currency() Currency {
+	return p.Amount.Currency
 }
 
 type Item struct {
 	ChartID     int
 	TrackNumber string
-	Price       int
+	Price       Money
 	RID         string
 	Name        string
 	Sale        int
 	Size        string
-	TotalPrice  int
+	TotalPrice  Money
 	NmID        int
 	Brand       string
 	Status      int
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// legacyOrderNamespace - пространство имен UUID v5 для order_uid не в формате UUID
+// (классическая модель WB L0: "b563feb7b2b84b6test"). Менять нельзя: изменятся ключи заказов
+var legacyOrderNamespace = uuid.MustParse("6f1c9a52-3d0e-4c8b-9a57-0b2e51d4c7a1")
+
+// ParseOrderUID переводит order_uid в ключ заказа. UUID берется как есть, другие строки
+// детерминированно отображаются в UUID v5; mapped сообщает, что исходный id нужно сохранить
+func ParseOrderUID(s string) (id uuid.UUID, mapped bool, err error) {
+	raw := strings.TrimSpace(s)
+	if raw == "" {
+		return uuid.Nil, false, fmt.Errorf("empty order_uid")
+	}
+	if id, err := uuid.Parse(raw); err == nil {
+		return id, false, nil
+	}
+	return uuid.NewSHA1(legacyOrderNamespace, []byte(raw)), true, nil
+}
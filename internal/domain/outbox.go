@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Типы событий заказа, которые публикуются через outbox
+const (
+	OrderCreated       = "order.created"
+	OrderUpdated       = "order.updated"
+	OrderStatusChanged = "order.status_changed"
+)
+
+// Статусы заказа в событиях order.status_changed
+const (
+	OrderStatusDeleted  = "deleted"
+	OrderStatusPurged   = "purged"
+	OrderStatusArchived = "archived"
+)
+
+// OutboxEvent - событие, записанное в одной транзакции с изменением заказа и ожидающее публикации
+type OutboxEvent struct {
+	ID        int64
+	Tenant    string
+	OrderUID  uuid.UUID
+	Type      string
+	Payload   []byte
+	CreatedAt time.Time
+	Attempts  int
+}
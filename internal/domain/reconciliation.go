@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type MismatchRule string
+
+const (
+	// RuleAmountTotal - amount = goods_total + delivery_cost + custom_fee
+	RuleAmountTotal MismatchRule = "amount_total"
+	// RuleGoodsTotal - goods_total = сумма total_price товаров
+	RuleGoodsTotal MismatchRule = "goods_total"
+	// RuleUnknownCurrency - валюта платежа есть в ISO-4217
+	RuleUnknownCurrency MismatchRule = "unknown_currency"
+)
+
+type MismatchSource string
+
+const (
+	MismatchSourceIngest MismatchSource = "ingest"
+	MismatchSourceScan   MismatchSource = "scan"
+)
+
+// PaymentTotals - суммы заказа, участвующие в сверке
+type PaymentTotals struct {
+	Currency     string
+	Amount       int64
+	GoodsTotal   int64
+	DeliveryCost int64
+	CustomFee    int64
+	ItemsTotal   int64
+}
+
+type OrderTotals struct {
+	Order  OrderKey
+	Totals PaymentTotals
+}
+
+type Mismatch struct {
+	Tenant   string
+	OrderUID uuid.UUID
+	Rule     MismatchRule
+	Expected int64
+	Actual   int64
+	// Подробности нарушения, например код неизвестной валюты
+	Detail     string
+	Source     MismatchSource
+	DetectedAt time.Time
+}
+
+type RuleCount struct {
+	Rule   MismatchRule
+	Orders int64
+}
+
+type MismatchReport struct {
+	Total      int64
+	ByRule     []RuleCount
+	Mismatches []Mismatch
+}
+
+type ScanReport struct {
+	Scanned    int64
+	Mismatches int64
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RetentionMode string
+
+const (
+	RetentionModePurge   RetentionMode = "purge"
+	RetentionModeArchive RetentionMode = "archive"
+)
+
+func ParseRetentionMode(s string) (RetentionMode, error) {
+	switch RetentionMode(s) {
+	case RetentionModePurge, RetentionModeArchive:
+		return RetentionMode(s), nil
+	case "":
+		return RetentionModeArchive, nil
+	default:
+		return "", fmt.Errorf("unknown retention mode: %q", s)
+	}
+}
+
+type RetentionPolicy struct {
+	OlderThan time.Duration
+	Mode      RetentionMode
+}
+
+type RetentionReport struct {
+	Mode   RetentionMode
+	Before time.Time
+	// Удаленные заказы всех витрин
+	Orders []OrderKey
+}
+
+// ErasedValue - значение, которым затираются персональные данные доставки
+const ErasedValue = "erased"
+
+type ErasureReport struct {
+	Tenant               string
+	CustomerID           string
+	OrderUIDs            []uuid.UUID
+	DeliveriesAnonymised int64
+	// Снимки в orders_archive и события outbox, в которых затерта доставка
+	ArchivedAnonymised int64
+	EventsAnonymised   int64
+	ErasedAt           time.Time
+}
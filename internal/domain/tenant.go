@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"strings"
+)
+
+// DefaultTenant - витрина заказов, для которых витрина не определена
+const DefaultTenant = "default"
+
+var tenantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+func ParseTenant(s string) (string, error) {
+	tenant := strings.ToLower(strings.TrimSpace(s))
+	if tenant == "" {
+		return DefaultTenant, nil
+	}
+	if !tenantPattern.MatchString(tenant) {
+		return "", fmt.Errorf("%w %q", ErrInvalidTenant, s)
+	}
+	return tenant, nil
+}
+
+// OrderKey - ключ заказа: order_uid уникален только внутри витрины
+type OrderKey struct {
+	Tenant   string
+	OrderUID uuid.UUID
+}
+
+func (k OrderKey) String() string {
+	return k.Tenant + "/" + k.OrderUID.String()
+}
+
+// CustomerKey - покупатель витрины: один customer_id в разных витринах - разные покупатели
+type CustomerKey struct {
+	Tenant     string
+	CustomerID string
+}
+
+// Статусы жизненного цикла заказа
+const (
+	OrderStatusCreated   = "created"
+	OrderStatusPaid      = "paid"
+	OrderStatusAssembled = "assembled"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusReturned  = "returned"
+	OrderStatusCancelled = "cancelled"
+)
+
+func ParseOrderStatus(s string) (string, error) {
+	switch status := strings.ToLower(strings.TrimSpace(s)); status {
+	case OrderStatusCreated, OrderStatusPaid, OrderStatusAssembled, OrderStatusShipped,
+		OrderStatusDelivered, OrderStatusReturned, OrderStatusCancelled:
+		return status, nil
+	default:
+		return "", fmt.Errorf("unknown order status %q", s)
+	}
+}
+
+// StatusUpdate - смена статуса заказа витрины. Заказ другой витрины не меняется
+type StatusUpdate struct {
+	Tenant   string
+	OrderUID uuid.UUID
+	Status   string
+	Reason   string
+}
+
+func (u StatusUpdate) Key() OrderKey {
+	return OrderKey{Tenant: u.Tenant, OrderUID: u.OrderUID}
+}
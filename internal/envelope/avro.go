@@ -0,0 +1,310 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"encoding/binary"
+	"fmt"
+	"github.com/hamba/avro/v2"
+	"time"
+)
+
+const ContentTypeAvro = "application/avro"
+
+// Первый байт сообщения в формате Confluent, за ним 4 байта id схемы (big-endian)
+const avroMagicByte = 0
+
+// AvroCodec пишет сообщения в формате Confluent: magic byte, id схемы из реестра, данные Avro.
+// Версия схемы заказа определяется по id
+type AvroCodec struct {
+	registry *Registry
+}
+
+type avroEnvelope[T any] struct {
+	EventType  string `avro:"event_type"`
+	ProducedAt int64  `avro:"produced_at"`
+	Payload    T      `avro:"payload"`
+}
+
+// Записи Avro повторяют схемы schemas/order-v*.avsc; доменные типы и OrderV2 от схем не зависят
+type avroOrder[I any] struct {
+	OrderUID          string       `avro:"order_uid"`
+	TrackNumber       string       `avro:"track_number"`
+	Entry             string       `avro:"entry"`
+	Delivery          avroDelivery `avro:"delivery"`
+	Payment           avroPayment  `avro:"payment"`
+	Items             []I          `avro:"items"`
+	Locale            string       `avro:"locale"`
+	InternalSignature string       `avro:"internal_signature"`
+	CustomerID        string       `avro:"customer_id"`
+	DeliveryService   string       `avro:"delivery_service"`
+	ShardKey          string       `avro:"shard_key"`
+	SmID              int64        `avro:"sm_id"`
+	OofShard          string       `avro:"oof_shard"`
+}
+
+type avroOrderV1 struct {
+	avroOrder[avroItemV1]
+	DateCreated string `avro:"date_created"`
+}
+
+type avroOrderV2 struct {
+	avroOrder[avroItemV2]
+	CreatedAt int64 `avro:"created_at"`
+}
+
+type avroDelivery struct {
+	Name    string `avro:"name"`
+	Phone   string `avro:"phone"`
+	Zip     string `avro:"zip"`
+	City    string `avro:"city"`
+	Address string `avro:"address"`
+	Region  string `avro:"region"`
+	Email   string `avro:"email"`
+}
+
+type avroPayment struct {
+	Transaction  string `avro:"transaction"`
+	RequestID    string `avro:"request_id"`
+	Currency     string `avro:"currency"`
+	Provider     string `avro:"provider"`
+	Amount       int64  `avro:"amount"`
+	PaymentDt    int64  `avro:"payment_dt"`
+	Bank         string `avro:"bank"`
+	DeliveryCost int64  `avro:"delivery_cost"`
+	GoodsTotal   int64  `avro:"goods_total"`
+	CustomFee    int64  `avro:"custom_fee"`
+}
+
+type avroItem struct {
+	TrackNumber string `avro:"track_number"`
+	Price       int64  `avro:"price"`
+	Rid         string `avro:"rid"`
+	Name        string `avro:"name"`
+	Sale        int64  `avro:"sale"`
+	Size        string `avro:"size"`
+	TotalPrice  int64  `avro:"total_price"`
+	NmID        int64  `avro:"nm_id"`
+	Brand       string `avro:"brand"`
+	Status      int64  `avro:"status"`
+}
+
+type avroItemV1 struct {
+	ChrtID int64 `avro:"chrt_id"`
+	avroItem
+}
+
+type avroItemV2 struct {
+	ChartID int64 `avro:"chart_id"`
+	avroItem
+}
+
+func NewAvroCodec(registry *Registry) (*AvroCodec, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("avro codec requires a schema registry")
+	}
+	return &AvroCodec{registry: registry}, nil
+}
+
+func (c *AvroCodec) ContentType() string {
+	return ContentTypeAvro
+}
+
+func (c *AvroCodec) Encode(env Envelope) ([]byte, error) {
+	schema, err := c.registry.Lookup(OrderSubject, env.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var record any
+	switch order := env.Payload.(type) {
+	case *domain.CompleteFakeOrder:
+		record = avroEnvelope[avroOrderV1]{EventType: env.EventType, ProducedAt: env.ProducedAt.UnixMilli(), Payload: toAvroV1(order)}
+	case *OrderV2:
+		record = avroEnvelope[avroOrderV2]{EventType: env.EventType, ProducedAt: env.ProducedAt.UnixMilli(), Payload: toAvroV2(order)}
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, env.SchemaVersion)
+	}
+
+	data, err := avro.Marshal(schema.parsed, record)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding avro: %v", err)
+	}
+
+	out := make([]byte, 5, 5+len(data))
+	out[0] = avroMagicByte
+	binary.BigEndian.PutUint32(out[1:5], uint32(schema.ID))
+	return append(out, data...), nil
+}
+
+func (c *AvroCodec) Decode(data []byte) (Envelope, error) {
+	if len(data) < 5 || data[0] != avroMagicByte {
+		return Envelope{}, fmt.Errorf("not an avro message: missing magic byte and schema id")
+	}
+	schema, err := c.registry.ByID(int(binary.BigEndian.Uint32(data[1:5])))
+	if err != nil {
+		return Envelope{}, err
+	}
+	if schema.Subject != OrderSubject {
+		return Envelope{}, fmt.Errorf("unexpected schema subject %q", schema.Subject)
+	}
+
+	env := Envelope{SchemaVersion: schema.Version}
+	switch schema.Version {
+	case Version1:
+		var record avroEnvelope[avroOrderV1]
+		if err := avro.Unmarshal(schema.parsed, data[5:], &record); err != nil {
+			return Envelope{}, fmt.Errorf("error decoding order v1: %v", err)
+		}
+		env.EventType, env.ProducedAt, env.Payload = record.EventType, time.UnixMilli(record.ProducedAt), fromAvroV1(record.Payload)
+	case Version2:
+		var record avroEnvelope[avroOrderV2]
+		if err := avro.Unmarshal(schema.parsed, data[5:], &record); err != nil {
+			return Envelope{}, fmt.Errorf("error decoding order v2: %v", err)
+		}
+		env.EventType, env.ProducedAt, env.Payload = record.EventType, time.UnixMilli(record.ProducedAt), fromAvroV2(record.Payload)
+	default:
+		return Envelope{}, fmt.Errorf("%w: %d", ErrUnknownVersion, schema.Version)
+	}
+	return env, nil
+}
+
+// Версия 1 хранит те же поля, что и версия 2, кроме даты создания и имени chrt_id,
+// поэтому записи собираются через OrderV2 (fromV1/toV1)
+func toAvroV1(v1 *domain.CompleteFakeOrder) avroOrderV1 {
+	v2 := fromV1(v1)
+	items := make([]avroItemV1, len(v2.Items))
+	for i, item := range v2.Items {
+		items[i] = avroItemV1{ChrtID: int64(item.ChartID), avroItem: toAvroItem(item)}
+	}
+	return avroOrderV1{avroOrder: toAvroOrder(v2, items), DateCreated: v1.DateCreated}
+}
+
+func toAvroV2(v2 *OrderV2) avroOrderV2 {
+	items := make([]avroItemV2, len(v2.Items))
+	for i, item := range v2.Items {
+		items[i] = avroItemV2{ChartID: int64(item.ChartID), avroItem: toAvroItem(item)}
+	}
+	return avroOrderV2{avroOrder: toAvroOrder(v2, items), CreatedAt: v2.CreatedAt}
+}
+
+func fromAvroV1(r avroOrderV1) *domain.CompleteFakeOrder {
+	v2 := fromAvroOrder(r.avroOrder)
+	for _, item := range r.Items {
+		v2.Items = append(v2.Items, fromAvroItem(int(item.ChrtID), item.avroItem))
+	}
+	return toV1(v2, r.DateCreated)
+}
+
+func fromAvroV2(r avroOrderV2) *OrderV2 {
+	v2 := fromAvroOrder(r.avroOrder)
+	for _, item := range r.Items {
+		v2.Items = append(v2.Items, fromAvroItem(int(item.ChartID), item.avroItem))
+	}
+	v2.CreatedAt = r.CreatedAt
+	return v2
+}
+
+func toAvroOrder[I any](o *OrderV2, items []I) avroOrder[I] {
+	return avroOrder[I]{
+		OrderUID:    o.OrderUID,
+		TrackNumber: o.TrackNumber,
+		Entry:       o.Entry,
+		Delivery: avroDelivery{
+			Name:    o.Delivery.Name,
+			Phone:   o.Delivery.Phone,
+			Zip:     o.Delivery.Zip,
+			City:    o.Delivery.City,
+			Address: o.Delivery.Address,
+			Region:  o.Delivery.Region,
+			Email:   o.Delivery.Email,
+		},
+		Payment: avroPayment{
+			Transaction:  o.Payment.Transaction,
+			RequestID:    o.Payment.RequestID,
+			Currency:     o.Payment.Currency,
+			Provider:     o.Payment.Provider,
+			Amount:       int64(o.Payment.Amount),
+			PaymentDt:    int64(o.Payment.PaymentDt),
+			Bank:         o.Payment.Bank,
+			DeliveryCost: int64(o.Payment.DeliveryCost),
+			GoodsTotal:   int64(o.Payment.GoodsTotal),
+			CustomFee:    int64(o.Payment.CustomFee),
+		},
+		Items:             items,
+		Locale:            o.Locale,
+		InternalSignature: o.InternalSignature,
+		CustomerID:        o.CustomerID,
+		DeliveryService:   o.DeliveryService,
+		ShardKey:          o.ShardKey,
+		SmID:              int64(o.SmID),
+		OofShard:          o.OofShard,
+	}
+}
+
+// fromAvroOrder переносит общие поля, позиции добавляет вызывающий
+func fromAvroOrder[I any](r avroOrder[I]) *OrderV2 {
+	return &OrderV2{
+		OrderUID:    r.OrderUID,
+		TrackNumber: r.TrackNumber,
+		Entry:       r.Entry,
+		Delivery: domain.FakeDelivery{
+			Name:    r.Delivery.Name,
+			Phone:   r.Delivery.Phone,
+			Zip:     r.Delivery.Zip,
+			City:    r.Delivery.City,
+			Address: r.Delivery.Address,
+			Region:  r.Delivery.Region,
+			Email:   r.Delivery.Email,
+		},
+		Payment: domain.FakePayment{
+			Transaction:  r.Payment.Transaction,
+			RequestID:    r.Payment.RequestID,
+			Currency:     r.Payment.Currency,
+			Provider:     r.Payment.Provider,
+			Amount:       int(r.Payment.Amount),
+			PaymentDt:    int(r.Payment.PaymentDt),
+			Bank:         r.Payment.Bank,
+			DeliveryCost: int(r.Payment.DeliveryCost),
+			GoodsTotal:   int(r.Payment.GoodsTotal),
+			CustomFee:    int(r.Payment.CustomFee),
+		},
+		Locale:            r.Locale,
+		InternalSignature: r.InternalSignature,
+		CustomerID:        r.CustomerID,
+		DeliveryService:   r.DeliveryService,
+		ShardKey:          r.ShardKey,
+		SmID:              int(r.SmID),
+		OofShard:          r.OofShard,
+	}
+}
+
+func toAvroItem(i ItemV2) avroItem {
+	return avroItem{
+		TrackNumber: i.TrackNumber,
+		Price:       int64(i.Price),
+		Rid:         i.Rid,
+		Name:        i.Name,
+		Sale:        int64(i.Sale),
+		Size:        i.Size,
+		TotalPrice:  int64(i.TotalPrice),
+		NmID:        int64(i.NmID),
+		Brand:       i.Brand,
+		Status:      int64(i.Status),
+	}
+}
+
+func fromAvroItem(chartID int, i avroItem) ItemV2 {
+	return ItemV2{
+		ChartID:     chartID,
+		TrackNumber: i.TrackNumber,
+		Price:       int(i.Price),
+		Rid:         i.Rid,
+		Name:        i.Name,
+		Sale:        int(i.Sale),
+		Size:        i.Size,
+		TotalPrice:  int(i.TotalPrice),
+		NmID:        int(i.NmID),
+		Brand:       i.Brand,
+		Status:      int(i.Status),
+	}
+}
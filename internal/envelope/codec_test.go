@@ -0,0 +1,154 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// modelV1 - канонический model.json, разобранный как заказ версии 1
+func modelV1(t *testing.T) *domain.CompleteFakeOrder {
+	t.Helper()
+	data, err := os.ReadFile("testdata/model.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, _, err := DecodeOrderV1(data)
+	if err != nil {
+		t.Fatalf("DecodeOrderV1: %v", err)
+	}
+	return order
+}
+
+func TestCodecsRoundTrip(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	codecs, err := NewCodecs(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	producedAt := time.UnixMilli(1637907739123)
+
+	for _, name := range []string{CodecJSON, CodecProtobuf, CodecAvro} {
+		for _, version := range []int{Version1, Version2} {
+			t.Run(fmt.Sprintf("%s/v%d", name, version), func(t *testing.T) {
+				codec, err := codecs.ByName(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				env, err := NewOrderEnvelope(modelV1(t), version, producedAt)
+				if err != nil {
+					t.Fatalf("NewOrderEnvelope: %v", err)
+				}
+
+				data, err := codec.Encode(env)
+				if err != nil {
+					t.Fatalf("Encode: %v", err)
+				}
+				byType, err := codecs.ByContentType(codec.ContentType())
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := byType.Decode(data)
+				if err != nil {
+					t.Fatalf("Decode: %v", err)
+				}
+
+				if got.EventType != env.EventType || got.SchemaVersion != version || !got.ProducedAt.Equal(producedAt) {
+					t.Errorf("envelope = %s v%d %s, want %s v%d %s",
+						got.EventType, got.SchemaVersion, got.ProducedAt, env.EventType, version, producedAt)
+				}
+				if !reflect.DeepEqual(got.Payload, env.Payload) {
+					t.Errorf("payload = %+v, want %+v", got.Payload, env.Payload)
+				}
+			})
+		}
+	}
+}
+
+func TestAvroUnknownSchemaID(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := NewAvroCodec(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := codec.Decode([]byte{avroMagicByte, 0, 0, 0, 99, 0}); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("Decode error = %v, want ErrSchemaNotFound", err)
+	}
+}
+
+func TestUpcastV1(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		created string
+		wantErr bool
+	}{
+		{name: "utc", date: "2021-11-26T06:22:19Z", created: "2021-11-26T06:22:19Z"},
+		{name: "offset", date: "2021-11-26T09:22:19+03:00", created: "2021-11-26T06:22:19Z"},
+		{name: "not rfc3339", date: "31/12/2024 25:61", wantErr: true},
+		{name: "empty", date: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1 := modelV1(t)
+			v1.DateCreated = tt.date
+
+			v2, err := UpcastV1(v1)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("UpcastV1 accepted an invalid date_created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpcastV1: %v", err)
+			}
+			if created := time.UnixMilli(v2.CreatedAt).UTC().Format(time.RFC3339); created != tt.created {
+				t.Errorf("created_at = %s, want %s", created, tt.created)
+			}
+			if len(v2.Items) != len(v1.Items) || v2.Items[0].ChartID != v1.Items[0].ChrtID {
+				t.Errorf("items = %+v, want chart_id from chrt_id %+v", v2.Items, v1.Items)
+			}
+
+			//Кроме даты и имени chrt_id версии переводятся друг в друга без потерь
+			if back := toV1(v2, v1.DateCreated); !reflect.DeepEqual(back, v1) {
+				t.Errorf("toV1(UpcastV1(v1)) = %+v, want %+v", back, v1)
+			}
+		})
+	}
+}
+
+func TestUpcast(t *testing.T) {
+	v1 := modelV1(t)
+	v2, err := UpcastV1(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Upcast(Envelope{SchemaVersion: Version1, Payload: v1})
+	if err != nil {
+		t.Fatalf("Upcast v1: %v", err)
+	}
+	if !reflect.DeepEqual(got, v2) {
+		t.Errorf("Upcast v1 = %+v, want %+v", got, v2)
+	}
+
+	if got, err := Upcast(Envelope{SchemaVersion: Version2, Payload: v2}); err != nil || got != v2 {
+		t.Errorf("Upcast v2 = %p, %v, want the payload as is", got, err)
+	}
+
+	if _, err := Upcast(Envelope{SchemaVersion: 3, Payload: "order"}); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Upcast unknown error = %v, want ErrUnknownVersion", err)
+	}
+}
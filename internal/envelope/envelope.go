@@ -0,0 +1,246 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Версии схемы заказа. Продюсер может писать любую из них, consumer приводит все к LatestVersion
+const (
+	Version1      = 1
+	Version2      = 2
+	LatestVersion = Version2
+)
+
+var (
+	ErrUnknownVersion = errors.New("unknown schema version")
+	ErrUnknownCodec   = errors.New("unknown codec")
+)
+
+// Envelope - версионированная обертка сообщения с заказом.
+// Payload - *domain.CompleteFakeOrder для версии 1 и *OrderV2 для версии 2
+type Envelope struct {
+	EventType     string
+	SchemaVersion int
+	ProducedAt    time.Time
+	Payload       any
+	// Поправки совместимости, примененные при разборе
+	Shims []Shim
+}
+
+// OrderV2 - заказ версии 2: дата создания в миллисекундах Unix вместо строки RFC3339,
+// chrt_id переименован в chart_id
+type OrderV2 struct {
+	OrderUID          string              `json:"order_uid"`
+	TrackNumber       string              `json:"track_number"`
+	Entry             string              `json:"entry"`
+	Delivery          domain.FakeDelivery `json:"delivery"`
+	Payment           domain.FakePayment  `json:"payment"`
+	Items             []ItemV2            `json:"items"`
+	Locale            string              `json:"locale"`
+	InternalSignature string              `json:"internal_signature"`
+	CustomerID        string              `json:"customer_id"`
+	DeliveryService   string              `json:"delivery_service"`
+	ShardKey          string              `json:"shard_key"`
+	SmID              int                 `json:"sm_id"`
+	CreatedAt         int64               `json:"created_at"`
+	OofShard          string              `json:"oof_shard"`
+}
+
+type ItemV2 struct {
+	ChartID     int    `json:"chart_id"`
+	TrackNumber string `json:"track_number"`
+	Price       int    `json:"price"`
+	Rid         string `json:"rid"`
+	Name        string `json:"name"`
+	Sale        int    `json:"sale"`
+	Size        string `json:"size"`
+	TotalPrice  int    `json:"total_price"`
+	NmID        int    `json:"nm_id"`
+	Brand       string `json:"brand"`
+	Status      int    `json:"status"`
+}
+
+// NewOrderEnvelope оборачивает заказ в конверт нужной версии. Заказ версии 1 передается как есть,
+// поэтому заведомо невалидные заказы генератора доходят до consumer
+func NewOrderEnvelope(order *domain.CompleteFakeOrder, version int, producedAt time.Time) (Envelope, error) {
+	env := Envelope{
+		EventType:     domain.OrderCreated,
+		SchemaVersion: version,
+		ProducedAt:    producedAt,
+	}
+	switch version {
+	case Version1:
+		env.Payload = order
+	case Version2:
+		v2, err := UpcastV1(order)
+		if err != nil {
+			return Envelope{}, err
+		}
+		env.Payload = v2
+	default:
+		return Envelope{}, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return env, nil
+}
+
+// Upcast приводит заказ из конверта к последней версии схемы
+func Upcast(env Envelope) (*OrderV2, error) {
+	switch order := env.Payload.(type) {
+	case *OrderV2:
+		return order, nil
+	case *domain.CompleteFakeOrder:
+		return UpcastV1(order)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, env.SchemaVersion)
+	}
+}
+
+// UpcastV1 переводит заказ версии 1 в версию 2
+func UpcastV1(v1 *domain.CompleteFakeOrder) (*OrderV2, error) {
+	created, err := time.Parse(time.RFC3339, v1.DateCreated)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date_created %q: %v", v1.DateCreated, err)
+	}
+	v2 := fromV1(v1)
+	v2.CreatedAt = created.UnixMilli()
+	return v2, nil
+}
+
+// fromV1 переносит общие для версий поля; дата создания остается пустой
+func fromV1(v1 *domain.CompleteFakeOrder) *OrderV2 {
+	items := make([]ItemV2, len(v1.Items))
+	for i, item := range v1.Items {
+		items[i] = ItemV2{
+			ChartID:     item.ChrtID,
+			TrackNumber: item.TrackNumber,
+			Price:       item.Price,
+			Rid:         item.Rid,
+			Name:        item.Name,
+			Sale:        item.Sale,
+			Size:        item.Size,
+			TotalPrice:  item.TotalPrice,
+			NmID:        item.NmID,
+			Brand:       item.Brand,
+			Status:      item.Status,
+		}
+	}
+
+	return &OrderV2{
+		OrderUID:          v1.OrderUID,
+		TrackNumber:       v1.TrackNumber,
+		Entry:             v1.Entry,
+		Delivery:          v1.Delivery,
+		Payment:           v1.Payment,
+		Items:             items,
+		Locale:            v1.Locale,
+		InternalSignature: v1.InternalSignature,
+		CustomerID:        v1.CustomerID,
+		DeliveryService:   v1.DeliveryService,
+		ShardKey:          v1.ShardKey,
+		SmID:              v1.SmID,
+		OofShard:          v1.OofShard,
+	}
+}
+
+// toV1 - обратное к fromV1 преобразование, дата создания передается отдельно
+func toV1(v2 *OrderV2, dateCreated string) *domain.CompleteFakeOrder {
+	items := make([]domain.FakeItem, len(v2.Items))
+	for i, item := range v2.Items {
+		items[i] = domain.FakeItem{
+			ChrtID:      item.ChartID,
+			TrackNumber: item.TrackNumber,
+			Price:       item.Price,
+			Rid:         item.Rid,
+			Name:        item.Name,
+			Sale:        item.Sale,
+			Size:        item.Size,
+			TotalPrice:  item.TotalPrice,
+			NmID:        item.NmID,
+			Brand:       item.Brand,
+			Status:      item.Status,
+		}
+	}
+
+	return &domain.CompleteFakeOrder{
+		OrderUID:          v2.OrderUID,
+		TrackNumber:       v2.TrackNumber,
+		Entry:             v2.Entry,
+		Delivery:          v2.Delivery,
+		Payment:           v2.Payment,
+		Items:             items,
+		Locale:            v2.Locale,
+		InternalSignature: v2.InternalSignature,
+		CustomerID:        v2.CustomerID,
+		DeliveryService:   v2.DeliveryService,
+		ShardKey:          v2.ShardKey,
+		SmID:              v2.SmID,
+		DateCreated:       dateCreated,
+		OofShard:          v2.OofShard,
+	}
+}
+
+// Codec кодирует конверт целиком; тип содержимого уходит в заголовок content_type
+type Codec interface {
+	ContentType() string
+	Encode(env Envelope) ([]byte, error)
+	Decode(data []byte) (Envelope, error)
+}
+
+// Названия кодеков в конфигурации
+const (
+	CodecJSON     = "json"
+	CodecProtobuf = "protobuf"
+	CodecAvro     = "avro"
+)
+
+// Codecs - все кодеки, consumer выбирает нужный по content_type сообщения
+type Codecs struct {
+	byName        map[string]Codec
+	byContentType map[string]Codec
+}
+
+func NewCodecs(registry *Registry) (*Codecs, error) {
+	avroCodec, err := NewAvroCodec(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Codecs{
+		byName: map[string]Codec{
+			CodecJSON:     JSONCodec{},
+			CodecProtobuf: ProtobufCodec{},
+			CodecAvro:     avroCodec,
+		},
+		byContentType: map[string]Codec{},
+	}
+	for _, codec := range c.byName {
+		c.byContentType[codec.ContentType()] = codec
+	}
+	return c, nil
+}
+
+func (c *Codecs) ByName(name string) (Codec, error) {
+	if name == "" {
+		name = CodecJSON
+	}
+	codec, ok := c.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCodec, name)
+	}
+	return codec, nil
+}
+
+// ByContentType возвращает кодек для заголовка content_type; без заголовка - JSON
+func (c *Codecs) ByContentType(contentType string) (Codec, error) {
+	if contentType == "" {
+		return c.byName[CodecJSON], nil
+	}
+	codec, ok := c.byContentType[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: content type %q", ErrUnknownCodec, contentType)
+	}
+	return codec, nil
+}
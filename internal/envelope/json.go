@@ -0,0 +1,74 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const ContentTypeJSON = "application/json"
+
+// JSONCodec пишет конверт как {"event_type", "schema_version", "produced_at", "payload"}.
+// Сообщения без конверта (заказ в корне документа) читаются как версия 1.
+// Заказы версии 1 разбираются терпимо, см. DecodeOrderV1
+type JSONCodec struct{}
+
+type jsonEnvelope struct {
+	EventType     string          `json:"event_type"`
+	SchemaVersion int             `json:"schema_version"`
+	ProducedAt    time.Time       `json:"produced_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func (JSONCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (JSONCodec) Encode(env Envelope) ([]byte, error) {
+	payload, err := json.Marshal(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonEnvelope{
+		EventType:     env.EventType,
+		SchemaVersion: env.SchemaVersion,
+		ProducedAt:    env.ProducedAt,
+		Payload:       payload,
+	})
+}
+
+func (JSONCodec) Decode(data []byte) (Envelope, error) {
+	var raw jsonEnvelope
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Envelope{}, err
+	}
+
+	//Заказ без конверта от старых продюсеров
+	if len(raw.Payload) == 0 {
+		raw = jsonEnvelope{EventType: domain.OrderCreated, SchemaVersion: Version1, Payload: data}
+	}
+
+	env := Envelope{
+		EventType:     raw.EventType,
+		SchemaVersion: raw.SchemaVersion,
+		ProducedAt:    raw.ProducedAt,
+	}
+	switch raw.SchemaVersion {
+	case Version1:
+		order, shims, err := DecodeOrderV1(raw.Payload)
+		if err != nil {
+			return Envelope{}, fmt.Errorf("error decoding order v1: %v", err)
+		}
+		env.Payload, env.Shims = order, shims
+	case Version2:
+		var order OrderV2
+		if err := json.Unmarshal(raw.Payload, &order); err != nil {
+			return Envelope{}, fmt.Errorf("error decoding order v2: %v", err)
+		}
+		env.Payload = &order
+	default:
+		return Envelope{}, fmt.Errorf("%w: %d", ErrUnknownVersion, raw.SchemaVersion)
+	}
+	return env, nil
+}
@@ -0,0 +1,183 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/generated/events/orderpb"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+const ContentTypeProtobuf = "application/x-protobuf"
+
+// ProtobufCodec кодирует сообщения по контракту api/events/order.proto, код сообщений
+// сгенерирован в internal/generated/events/orderpb
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (ProtobufCodec) Encode(env Envelope) ([]byte, error) {
+	var order *orderpb.Order
+	switch payload := env.Payload.(type) {
+	case *domain.CompleteFakeOrder:
+		order = toProtoOrder(fromV1(payload))
+		order.DateCreated = payload.DateCreated
+	case *OrderV2:
+		order = toProtoOrder(payload)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, env.SchemaVersion)
+	}
+
+	payload, err := proto.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding order: %v", err)
+	}
+	return proto.Marshal(&orderpb.Envelope{
+		EventType:     env.EventType,
+		SchemaVersion: uint32(env.SchemaVersion),
+		ProducedAtMs:  env.ProducedAt.UnixMilli(),
+		Payload:       payload,
+	})
+}
+
+func (ProtobufCodec) Decode(data []byte) (Envelope, error) {
+	var raw orderpb.Envelope
+	if err := proto.Unmarshal(data, &raw); err != nil {
+		return Envelope{}, fmt.Errorf("error decoding envelope: %v", err)
+	}
+	env := Envelope{
+		EventType:     raw.EventType,
+		SchemaVersion: int(raw.SchemaVersion),
+		ProducedAt:    time.UnixMilli(raw.ProducedAtMs),
+	}
+
+	var order orderpb.Order
+	if err := proto.Unmarshal(raw.Payload, &order); err != nil {
+		return Envelope{}, fmt.Errorf("error decoding order v%d: %v", env.SchemaVersion, err)
+	}
+	switch env.SchemaVersion {
+	case Version1:
+		env.Payload = toV1(fromProtoOrder(&order), order.DateCreated)
+	case Version2:
+		env.Payload = fromProtoOrder(&order)
+	default:
+		return Envelope{}, fmt.Errorf("%w: %d", ErrUnknownVersion, env.SchemaVersion)
+	}
+	return env, nil
+}
+
+// toProtoOrder переносит поля заказа в сообщение; date_created версии 1 заполняет вызывающий
+func toProtoOrder(o *OrderV2) *orderpb.Order {
+	items := make([]*orderpb.Item, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = &orderpb.Item{
+			ChartId:     int64(item.ChartID),
+			TrackNumber: item.TrackNumber,
+			Price:       int64(item.Price),
+			Rid:         item.Rid,
+			Name:        item.Name,
+			Sale:        int64(item.Sale),
+			Size:        item.Size,
+			TotalPrice:  int64(item.TotalPrice),
+			NmId:        int64(item.NmID),
+			Brand:       item.Brand,
+			Status:      int64(item.Status),
+		}
+	}
+
+	return &orderpb.Order{
+		OrderUid:    o.OrderUID,
+		TrackNumber: o.TrackNumber,
+		Entry:       o.Entry,
+		Delivery: &orderpb.Delivery{
+			Name:    o.Delivery.Name,
+			Phone:   o.Delivery.Phone,
+			Zip:     o.Delivery.Zip,
+			City:    o.Delivery.City,
+			Address: o.Delivery.Address,
+			Region:  o.Delivery.Region,
+			Email:   o.Delivery.Email,
+		},
+		Payment: &orderpb.Payment{
+			Transaction:  o.Payment.Transaction,
+			RequestId:    o.Payment.RequestID,
+			Currency:     o.Payment.Currency,
+			Provider:     o.Payment.Provider,
+			Amount:       int64(o.Payment.Amount),
+			PaymentDt:    int64(o.Payment.PaymentDt),
+			Bank:         o.Payment.Bank,
+			DeliveryCost: int64(o.Payment.DeliveryCost),
+			GoodsTotal:   int64(o.Payment.GoodsTotal),
+			CustomFee:    int64(o.Payment.CustomFee),
+		},
+		Items:             items,
+		Locale:            o.Locale,
+		InternalSignature: o.InternalSignature,
+		CustomerId:        o.CustomerID,
+		DeliveryService:   o.DeliveryService,
+		ShardKey:          o.ShardKey,
+		SmId:              int64(o.SmID),
+		OofShard:          o.OofShard,
+		CreatedAt:         o.CreatedAt,
+	}
+}
+
+// fromProtoOrder читает заказ любой версии; date_created версии 1 остается в сообщении
+func fromProtoOrder(o *orderpb.Order) *OrderV2 {
+	var items []ItemV2
+	for _, item := range o.Items {
+		items = append(items, ItemV2{
+			ChartID:     int(item.ChartId),
+			TrackNumber: item.TrackNumber,
+			Price:       int(item.Price),
+			Rid:         item.Rid,
+			Name:        item.Name,
+			Sale:        int(item.Sale),
+			Size:        item.Size,
+			TotalPrice:  int(item.TotalPrice),
+			NmID:        int(item.NmId),
+			Brand:       item.Brand,
+			Status:      int(item.Status),
+		})
+	}
+
+	//Геттеры сообщений безопасны для отсутствующих delivery и payment
+	delivery, payment := o.GetDelivery(), o.GetPayment()
+	return &OrderV2{
+		OrderUID:    o.OrderUid,
+		TrackNumber: o.TrackNumber,
+		Entry:       o.Entry,
+		Delivery: domain.FakeDelivery{
+			Name:    delivery.GetName(),
+			Phone:   delivery.GetPhone(),
+			Zip:     delivery.GetZip(),
+			City:    delivery.GetCity(),
+			Address: delivery.GetAddress(),
+			Region:  delivery.GetRegion(),
+			Email:   delivery.GetEmail(),
+		},
+		Payment: domain.FakePayment{
+			Transaction:  payment.GetTransaction(),
+			RequestID:    payment.GetRequestId(),
+			Currency:     payment.GetCurrency(),
+			Provider:     payment.GetProvider(),
+			Amount:       int(payment.GetAmount()),
+			PaymentDt:    int(payment.GetPaymentDt()),
+			Bank:         payment.GetBank(),
+			DeliveryCost: int(payment.GetDeliveryCost()),
+			GoodsTotal:   int(payment.GetGoodsTotal()),
+			CustomFee:    int(payment.GetCustomFee()),
+		},
+		Items:             items,
+		Locale:            o.Locale,
+		InternalSignature: o.InternalSignature,
+		CustomerID:        o.CustomerId,
+		DeliveryService:   o.DeliveryService,
+		ShardKey:          o.ShardKey,
+		SmID:              int(o.SmId),
+		CreatedAt:         o.CreatedAt,
+		OofShard:          o.OofShard,
+	}
+}
@@ -0,0 +1,147 @@
+package envelope
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/hamba/avro/v2"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// OrderSubject - subject схем заказа в реестре
+const OrderSubject = "order"
+
+var ErrSchemaNotFound = errors.New("schema not found")
+
+//go:embed schemas/*.avsc
+var embeddedSchemas embed.FS
+
+// Файлы схем называются <subject>-v<version>.avsc
+var schemaFileName = regexp.MustCompile(`^([a-z0-9_.]+)-v(\d+)\.avsc$`)
+
+type Schema struct {
+	ID         int
+	Subject    string
+	Version    int
+	Definition string
+	parsed     avro.Schema
+}
+
+// Registry - офлайн-замена Schema Registry: схемы Avro лежат в бинарнике и в каталоге на диске,
+// id назначаются по порядку регистрации. Встроенные схемы заказа получают id 1 (v1) и 2 (v2)
+type Registry struct {
+	mu        sync.RWMutex
+	byID      map[int]Schema
+	bySubject map[string]map[int]Schema
+	nextID    int
+}
+
+func NewRegistry() (*Registry, error) {
+	r := &Registry{
+		byID:      map[int]Schema{},
+		bySubject: map[string]map[int]Schema{},
+		nextID:    1,
+	}
+	for _, version := range []int{Version1, Version2} {
+		name := fmt.Sprintf("schemas/%s-v%d.avsc", OrderSubject, version)
+		definition, err := embeddedSchemas.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.Register(OrderSubject, version, string(definition)); err != nil {
+			return nil, fmt.Errorf("embedded schema %s: %v", name, err)
+		}
+	}
+	return r, nil
+}
+
+// LoadRegistry дополняет встроенные схемы файлами <subject>-v<version>.avsc из каталога dir
+func LoadRegistry(dir string) (*Registry, error) {
+	r, err := NewRegistry()
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return r, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema directory: %v", err)
+	}
+	for _, entry := range entries {
+		m := schemaFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[2])
+		definition, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading schema: %v", err)
+		}
+		if _, err := r.Register(m[1], version, string(definition)); err != nil {
+			return nil, fmt.Errorf("schema %s: %v", entry.Name(), err)
+		}
+	}
+	return r, nil
+}
+
+// Register добавляет схему. Повторная регистрация той же схемы возвращает существующую запись,
+// другая схема под занятой версией - ошибка
+func (r *Registry) Register(subject string, version int, definition string) (Schema, error) {
+	parsed, err := avro.Parse(definition)
+	if err != nil {
+		return Schema{}, fmt.Errorf("invalid avro schema: %v", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.bySubject[subject][version]; ok {
+		if existing.parsed.Fingerprint() != parsed.Fingerprint() {
+			return Schema{}, fmt.Errorf("subject %s version %d is already registered with a different schema", subject, version)
+		}
+		return existing, nil
+	}
+
+	schema := Schema{
+		ID:         r.nextID,
+		Subject:    subject,
+		Version:    version,
+		Definition: definition,
+		parsed:     parsed,
+	}
+	r.nextID++
+	r.byID[schema.ID] = schema
+	if r.bySubject[subject] == nil {
+		r.bySubject[subject] = map[int]Schema{}
+	}
+	r.bySubject[subject][version] = schema
+	return schema, nil
+}
+
+func (r *Registry) ByID(id int) (Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.byID[id]
+	if !ok {
+		return Schema{}, fmt.Errorf("%w: id %d", ErrSchemaNotFound, id)
+	}
+	return schema, nil
+}
+
+func (r *Registry) Lookup(subject string, version int) (Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.bySubject[subject][version]
+	if !ok {
+		return Schema{}, fmt.Errorf("%w: %s version %d", ErrSchemaNotFound, subject, version)
+	}
+	return schema, nil
+}
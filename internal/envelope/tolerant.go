@@ -0,0 +1,249 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Виды поправок, которые терпимый декодер применяет к заказам версии 1
+const (
+	ShimFieldSpelling = "field_spelling"   // shardkey вместо shard_key, chart_id вместо chrt_id
+	ShimNumericString = "numeric_string"   // число строкой: "sm_id": "99"
+	ShimNumberString  = "number_as_string" // строковое поле числом: "oof_shard": 1
+	ShimFloatInt      = "float_as_int"     // целое с дробной частью: "price": 1817.0
+	ShimDateFormat    = "date_format"      // date_created не в RFC3339
+	ShimLegacyUID     = "legacy_order_uid" // order_uid не UUID: ключ заказа выводится через domain.ParseOrderUID
+)
+
+// Shim - поправка, примененная к сообщению при разборе
+type Shim struct {
+	Kind   string
+	Field  string
+	Detail string
+}
+
+func (s Shim) String() string {
+	if s.Detail != "" {
+		return s.Kind + ":" + s.Field + "(" + s.Detail + ")"
+	}
+	return s.Kind + ":" + s.Field
+}
+
+// Поля, которые в CompleteFakeOrder целые; остальные поля заказа - строки
+var (
+	orderIntFields   = map[string]bool{"sm_id": true}
+	paymentIntFields = map[string]bool{
+		"amount": true, "payment_dt": true, "delivery_cost": true, "goods_total": true, "custom_fee": true,
+	}
+	itemIntFields = map[string]bool{
+		"chrt_id": true, "price": true, "sale": true, "total_price": true, "nm_id": true, "status": true,
+	}
+)
+
+// Форматы date_created, кроме RFC3339. Время без зоны считается UTC
+var dateLayouts = []struct {
+	name   string
+	layout string
+}{
+	{"without_timezone", "2006-01-02T15:04:05"},
+	{"space_separator", "2006-01-02 15:04:05Z07:00"},
+	{"space_separator", "2006-01-02 15:04:05"},
+	{"date_only", time.DateOnly},
+}
+
+// shimDecoder собирает поправки одного сообщения
+type shimDecoder struct {
+	shims []Shim
+}
+
+// DecodeOrderV1 разбирает заказ версии 1 как во внутреннем формате, так и в классическом
+// формате модели WB L0: shardkey, chart_id, числа строками, даты в разных форматах.
+// Возвращает заказ в каноническом виде и список примененных поправок
+func DecodeOrderV1(data []byte) (*domain.CompleteFakeOrder, []Shim, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, nil, err
+	}
+
+	d := &shimDecoder{}
+	d.rename(raw, "", "shardkey", "shard_key")
+	if err := d.normalizeObject(raw, "", orderIntFields); err != nil {
+		return nil, nil, err
+	}
+	if err := d.normalizeDate(raw); err != nil {
+		return nil, nil, err
+	}
+	d.checkOrderUID(raw)
+	if delivery, ok := raw["delivery"].(map[string]any); ok {
+		if err := d.normalizeObject(delivery, "delivery.", nil); err != nil {
+			return nil, nil, err
+		}
+	}
+	if payment, ok := raw["payment"].(map[string]any); ok {
+		if err := d.normalizeObject(payment, "payment.", paymentIntFields); err != nil {
+			return nil, nil, err
+		}
+	}
+	if items, ok := raw["items"].([]any); ok {
+		for i, v := range items {
+			item, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			prefix := fmt.Sprintf("items[%d].", i)
+			d.rename(item, prefix, "chart_id", "chrt_id")
+			if err := d.normalizeObject(item, prefix, itemIntFields); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	//Нормализованный документ разбирается обычным строгим способом
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	var order domain.CompleteFakeOrder
+	if err := json.Unmarshal(normalized, &order); err != nil {
+		return nil, nil, err
+	}
+	return &order, d.shims, nil
+}
+
+func (d *shimDecoder) apply(kind, field, detail string) {
+	d.shims = append(d.shims, Shim{Kind: kind, Field: field, Detail: detail})
+}
+
+// rename переносит значение из альтернативного написания поля, если каноническое не задано
+func (d *shimDecoder) rename(obj map[string]any, prefix, from, to string) {
+	v, ok := obj[from]
+	if !ok {
+		return
+	}
+	delete(obj, from)
+	if _, exists := obj[to]; !exists {
+		obj[to] = v
+		d.apply(ShimFieldSpelling, prefix+to, from)
+	}
+}
+
+// normalizeObject приводит числа и строки к типам полей CompleteFakeOrder
+func (d *shimDecoder) normalizeObject(obj map[string]any, prefix string, intFields map[string]bool) error {
+	//Ключи по порядку, чтобы отчет о поправках был одинаковым для одинаковых сообщений
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "date_created" {
+			continue
+		}
+		field := prefix + key
+		switch val := obj[key].(type) {
+		case json.Number:
+			if !intFields[key] {
+				obj[key] = val.String()
+				d.apply(ShimNumberString, field, "")
+				continue
+			}
+			n, float, err := parseInt(val.String())
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %v", field, val, err)
+			}
+			if float {
+				obj[key] = n
+				d.apply(ShimFloatInt, field, "")
+			}
+		case string:
+			if !intFields[key] {
+				continue
+			}
+			n, _, err := parseInt(strings.TrimSpace(val))
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %v", field, val, err)
+			}
+			obj[key] = n
+			d.apply(ShimNumericString, field, "")
+		}
+	}
+	return nil
+}
+
+// parseInt разбирает целое, в том числе записанное с нулевой дробной частью; float сообщает о таком виде
+func parseInt(s string) (n int64, float bool, err error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, false, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	if f != math.Trunc(f) || math.Abs(f) > math.MaxInt64 {
+		return 0, false, fmt.Errorf("not an integer")
+	}
+	return int64(f), true, nil
+}
+
+// checkOrderUID отмечает order_uid не в формате UUID. Значение не меняется:
+// исходный id сохраняется вместе с заказом
+func (d *shimDecoder) checkOrderUID(obj map[string]any) {
+	s, ok := obj["order_uid"].(string)
+	if !ok {
+		return
+	}
+	if id, mapped, err := domain.ParseOrderUID(s); err == nil && mapped {
+		d.apply(ShimLegacyUID, "order_uid", id.String())
+	}
+}
+
+// normalizeDate приводит date_created к RFC3339. Неразборчивая дата остается как есть
+// и отклоняется при переводе в версию 2
+func (d *shimDecoder) normalizeDate(obj map[string]any) error {
+	var s string
+	switch v := obj["date_created"].(type) {
+	case string:
+		s = strings.TrimSpace(v)
+	case json.Number:
+		s = v.String()
+	default:
+		return nil
+	}
+
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return nil
+	}
+	t, detail, ok := parseDate(s)
+	if !ok {
+		return nil
+	}
+	obj["date_created"] = t.Format(time.RFC3339)
+	d.apply(ShimDateFormat, "date_created", detail)
+	return nil
+}
+
+func parseDate(s string) (time.Time, string, bool) {
+	for _, l := range dateLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return t, l.name, true
+		}
+	}
+	//Unix-время в секундах или миллисекундах
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n > 0 {
+		if n >= 1e11 {
+			return time.UnixMilli(n).UTC(), "unix_millis", true
+		}
+		return time.Unix(n, 0).UTC(), "unix_seconds", true
+	}
+	return time.Time{}, "", false
+}
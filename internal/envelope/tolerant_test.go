@@ -0,0 +1,262 @@
+package envelope
+
+import (
+	"L0WB/internal/domain"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	fixtureUUID = "4c0f4b3e-8f4e-4d5c-9a3b-2b1f0e6d7c8a"
+	// Ключ заказа model.json, выведенный из "b563feb7b2b84b6test"
+	modelOrderKey = "1a1e866f-01e8-5f84-b773-b1ee945f7e8a"
+)
+
+// loadModel читает канонический пример заказа модели WB L0
+func loadModel(t *testing.T) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile("testdata/model.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// internalModel - тот же заказ во внутреннем формате: поправки к нему не применяются
+func internalModel(t *testing.T) map[string]any {
+	raw := loadModel(t)
+	raw["order_uid"] = fixtureUUID
+	raw["shard_key"] = raw["shardkey"]
+	delete(raw, "shardkey")
+	return raw
+}
+
+func item(raw map[string]any) map[string]any {
+	return raw["items"].([]any)[0].(map[string]any)
+}
+
+func TestDecodeOrderV1Shims(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(raw map[string]any)
+		shims  []string
+		check  func(t *testing.T, order *domain.CompleteFakeOrder)
+	}{
+		{
+			name:   "internal format",
+			mutate: func(raw map[string]any) {},
+		},
+		{
+			name: "field_spelling shardkey",
+			mutate: func(raw map[string]any) {
+				raw["shardkey"] = raw["shard_key"]
+				delete(raw, "shard_key")
+			},
+			shims: []string{"field_spelling:shard_key(shardkey)"},
+			check: func(t *testing.T, order *domain.CompleteFakeOrder) {
+				if order.ShardKey != "9" {
+					t.Errorf("shard_key = %q, want 9", order.ShardKey)
+				}
+			},
+		},
+		{
+			name: "field_spelling chart_id",
+			mutate: func(raw map[string]any) {
+				it := item(raw)
+				it["chart_id"] = it["chrt_id"]
+				delete(it, "chrt_id")
+			},
+			shims: []string{"field_spelling:items[0].chrt_id(chart_id)"},
+			check: func(t *testing.T, order *domain.CompleteFakeOrder) {
+				if order.Items[0].ChrtID != 9934930 {
+					t.Errorf("chrt_id = %d, want 9934930", order.Items[0].ChrtID)
+				}
+			},
+		},
+		{
+			name:   "numeric_string",
+			mutate: func(raw map[string]any) { raw["sm_id"] = "99" },
+			shims:  []string{"numeric_string:sm_id"},
+			check: func(t *testing.T, order *domain.CompleteFakeOrder) {
+				if order.SmID != 99 {
+					t.Errorf("sm_id = %d, want 99", order.SmID)
+				}
+			},
+		},
+		{
+			name:   "number_as_string",
+			mutate: func(raw map[string]any) { raw["oof_shard"] = 1 },
+			shims:  []string{"number_as_string:oof_shard"},
+			check: func(t *testing.T, order *domain.CompleteFakeOrder) {
+				if order.OofShard != "1" {
+					t.Errorf("oof_shard = %q, want 1", order.OofShard)
+				}
+			},
+		},
+		{
+			name:   "float_as_int",
+			mutate: func(raw map[string]any) { item(raw)["price"] = json.Number("453.0") },
+			shims:  []string{"float_as_int:items[0].price"},
+			check: func(t *testing.T, order *domain.CompleteFakeOrder) {
+				if order.Items[0].Price != 453 {
+					t.Errorf("price = %d, want 453", order.Items[0].Price)
+				}
+			},
+		},
+		{
+			name:   "date_format without timezone",
+			mutate: func(raw map[string]any) { raw["date_created"] = "2021-11-26T06:22:19" },
+			shims:  []string{"date_format:date_created(without_timezone)"},
+			check:  checkDate("2021-11-26T06:22:19Z"),
+		},
+		{
+			name:   "date_format space separator",
+			mutate: func(raw map[string]any) { raw["date_created"] = "2021-11-26 06:22:19" },
+			shims:  []string{"date_format:date_created(space_separator)"},
+			check:  checkDate("2021-11-26T06:22:19Z"),
+		},
+		{
+			name:   "date_format unix seconds",
+			mutate: func(raw map[string]any) { raw["date_created"] = 1637907739 },
+			shims:  []string{"date_format:date_created(unix_seconds)"},
+			check:  checkDate("2021-11-26T06:22:19Z"),
+		},
+		{
+			name:   "legacy_order_uid",
+			mutate: func(raw map[string]any) { raw["order_uid"] = "b563feb7b2b84b6test" },
+			shims:  []string{"legacy_order_uid:order_uid(" + legacyUID(t, "b563feb7b2b84b6test") + ")"},
+			check: func(t *testing.T, order *domain.CompleteFakeOrder) {
+				if order.OrderUID != "b563feb7b2b84b6test" {
+					t.Errorf("order_uid = %q, want the original id", order.OrderUID)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := internalModel(t)
+			tt.mutate(raw)
+			data, err := json.Marshal(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			order, shims, err := DecodeOrderV1(data)
+			if err != nil {
+				t.Fatalf("DecodeOrderV1: %v", err)
+			}
+			if got := shimStrings(shims); !equal(got, tt.shims) {
+				t.Errorf("shims = %v, want %v", got, tt.shims)
+			}
+			if tt.check != nil {
+				tt.check(t, order)
+			}
+		})
+	}
+}
+
+func TestDecodeOrderV1Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(raw map[string]any)
+	}{
+		{"fractional integer", func(raw map[string]any) { item(raw)["price"] = 453.5 }},
+		{"non-numeric string", func(raw map[string]any) { raw["sm_id"] = "ninety-nine" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := internalModel(t)
+			tt.mutate(raw)
+			data, err := json.Marshal(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := DecodeOrderV1(data); err == nil {
+				t.Error("DecodeOrderV1 accepted an invalid order")
+			}
+		})
+	}
+}
+
+// Канонический model.json разбирается, переводится в версию 2 и получает стабильный ключ
+func TestDecodeOrderV1Model(t *testing.T) {
+	data, err := os.ReadFile("testdata/model.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, shims, err := DecodeOrderV1(data)
+	if err != nil {
+		t.Fatalf("DecodeOrderV1: %v", err)
+	}
+	want := []string{
+		"field_spelling:shard_key(shardkey)",
+		"legacy_order_uid:order_uid(" + legacyUID(t, "b563feb7b2b84b6test") + ")",
+	}
+	if got := shimStrings(shims); !equal(got, want) {
+		t.Errorf("shims = %v, want %v", got, want)
+	}
+
+	v2, err := UpcastV1(order)
+	if err != nil {
+		t.Fatalf("UpcastV1: %v", err)
+	}
+	if v2.OrderUID != "b563feb7b2b84b6test" || v2.ShardKey != "9" || v2.Payment.Currency != "USD" {
+		t.Errorf("unexpected order %+v", v2)
+	}
+	if created := time.UnixMilli(v2.CreatedAt).UTC().Format(time.RFC3339); created != "2021-11-26T06:22:19Z" {
+		t.Errorf("created_at = %s", created)
+	}
+	if len(v2.Items) != 1 || v2.Items[0].ChartID != 9934930 {
+		t.Errorf("items = %+v", v2.Items)
+	}
+
+	//Ключ уже сохраненных заказов не должен меняться
+	if id := legacyUID(t, v2.OrderUID); id != modelOrderKey {
+		t.Errorf("order key = %s, want %s", id, modelOrderKey)
+	}
+}
+
+func checkDate(want string) func(t *testing.T, order *domain.CompleteFakeOrder) {
+	return func(t *testing.T, order *domain.CompleteFakeOrder) {
+		if order.DateCreated != want {
+			t.Errorf("date_created = %q, want %q", order.DateCreated, want)
+		}
+	}
+}
+
+func legacyUID(t *testing.T, raw string) string {
+	id, mapped, err := domain.ParseOrderUID(raw)
+	if err != nil || !mapped {
+		t.Fatalf("ParseOrderUID(%q) = %v, %v, %v", raw, id, mapped, err)
+	}
+	return id.String()
+}
+
+func shimStrings(shims []Shim) []string {
+	var out []string
+	for _, s := range shims {
+		out = append(out, s.String())
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
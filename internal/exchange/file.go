@@ -0,0 +1,73 @@
+package exchange
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ratesFile - формат файла курсов: сколько единиц валюты стоит одна единица базовой
+type ratesFile struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// FileRateProvider отдает курсы из локального файла, пригоден для работы без сети
+type FileRateProvider struct {
+	base  string
+	rates map[string]float64
+}
+
+func NewFileRateProvider(path string) (*FileRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rates file: %v", err)
+	}
+
+	var f ratesFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("error parsing rates file: %v", err)
+	}
+
+	base, err := domain.ParseCurrency(f.Base)
+	if err != nil {
+		return nil, fmt.Errorf("rates file base: %w", err)
+	}
+
+	rates := map[string]float64{base.Code: 1}
+	for code, rate := range f.Rates {
+		c, err := domain.ParseCurrency(code)
+		if err != nil {
+			return nil, fmt.Errorf("rates file: %w", err)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("rates file: non-positive rate for %s", c.Code)
+		}
+		rates[c.Code] = rate
+	}
+
+	return &FileRateProvider{
+		base:  base.Code,
+		rates: rates,
+	}, nil
+}
+
+// Rate считает кросс-курс через базовую валюту
+func (p *FileRateProvider) Rate(_ context.Context, from, to domain.Currency) (float64, error) {
+	if from.Code == to.Code {
+		return 1, nil
+	}
+
+	fromRate, ok := p.rates[strings.ToUpper(from.Code)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s/%s", ErrRateNotFound, from.Code, p.base)
+	}
+	toRate, ok := p.rates[strings.ToUpper(to.Code)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s/%s", ErrRateNotFound, to.Code, p.base)
+	}
+	return toRate / fromRate, nil
+}
@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrRateNotFound       = errors.New("exchange rate not found")
+	ErrConversionDisabled = errors.New("currency conversion is not configured")
+)
+
+// RateProvider возвращает курс: сколько единиц to стоит одна единица from
+type RateProvider interface {
+	Rate(ctx context.Context, from, to domain.Currency) (float64, error)
+}
+
+type Converter struct {
+	provider RateProvider
+}
+
+func NewConverter(provider RateProvider) *Converter {
+	return &Converter{
+		provider: provider,
+	}
+}
+
+func (c *Converter) ConvertOrder(ctx context.Context, order *domain.Order, to string) (*domain.Order, domain.Conversion, error) {
+	if c.provider == nil {
+		return nil, domain.Conversion{}, ErrConversionDisabled
+	}
+
+	toCurrency, err := domain.ParseCurrency(to)
+	if err != nil {
+		return nil, domain.Conversion{}, err
+	}
+	fromCurrency, err := domain.ParseCurrency(order.Payment.Currency().Code)
+	if err != nil {
+		return nil, domain.Conversion{}, fmt.Errorf("order %s: %w", order.ID, err)
+	}
+
+	rate, err := c.provider.Rate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		return nil, domain.Conversion{}, err
+	}
+
+	conv := domain.Conversion{From: fromCurrency, To: toCurrency, Rate: rate}
+	converted := domain.ConvertOrder(*order, conv)
+	return &converted, conv, nil
+}
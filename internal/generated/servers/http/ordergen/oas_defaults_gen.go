@@ -0,0 +1,31 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package service
+
+// setDefaults set default value of fields.
+func (s *ConsumerReplayRequest) setDefaults() {
+	{
+		val := string("earliest")
+		s.From.SetTo(val)
+	}
+	{
+		val := string("latest")
+		s.To.SetTo(val)
+	}
+	{
+		val := ConsumerReplayRequestMode("dry-run")
+		s.Mode.SetTo(val)
+	}
+	{
+		val := int(10000)
+		s.Limit.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *GenerateOrdersRequest) setDefaults() {
+	{
+		val := int(1)
+		s.Count.SetTo(val)
+	}
+}
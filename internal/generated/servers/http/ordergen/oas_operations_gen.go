@@ -6,5 +6,24 @@ package service
 type OperationName = string
 
 const (
-	GetOrderOperation OperationName = "GetOrder"
+	ApplyRetentionOperation          OperationName = "ApplyRetention"
+	DeleteOrderOperation             OperationName = "DeleteOrder"
+	EraseCustomerOperation           OperationName = "EraseCustomer"
+	GenerateOrdersOperation          OperationName = "GenerateOrders"
+	GetConsumerStatusOperation       OperationName = "GetConsumerStatus"
+	GetCustomerSummaryOperation      OperationName = "GetCustomerSummary"
+	GetDeliveryServiceShareOperation OperationName = "GetDeliveryServiceShare"
+	GetDiscountStatsOperation        OperationName = "GetDiscountStats"
+	GetOrderOperation                OperationName = "GetOrder"
+	GetReconciliationReportOperation OperationName = "GetReconciliationReport"
+	GetRegionOrdersOperation         OperationName = "GetRegionOrders"
+	GetRevenueOperation              OperationName = "GetRevenue"
+	GetTopBrandsOperation            OperationName = "GetTopBrands"
+	GetTopItemsOperation             OperationName = "GetTopItems"
+	ListCustomerOrdersOperation      OperationName = "ListCustomerOrders"
+	PauseConsumerOperation           OperationName = "PauseConsumer"
+	ReplayConsumerOperation          OperationName = "ReplayConsumer"
+	ResumeConsumerOperation          OperationName = "ResumeConsumer"
+	RunReconciliationScanOperation   OperationName = "RunReconciliationScan"
+	SetConsumerRateOperation         OperationName = "SetConsumerRate"
 )
@@ -4,269 +4,2760 @@ package service
 
 import (
 	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// Ref: #/components/schemas/ApplyRetentionRequest
+type ApplyRetentionRequest struct {
+	OlderThanDays int                          `json:"older_than_days"`
+	Mode          OptApplyRetentionRequestMode `json:"mode"`
+}
+
+// GetOlderThanDays returns the value of OlderThanDays.
+func (s *ApplyRetentionRequest) GetOlderThanDays() int {
+	return s.OlderThanDays
+}
+
+// GetMode returns the value of Mode.
+func (s *ApplyRetentionRequest) GetMode() OptApplyRetentionRequestMode {
+	return s.Mode
+}
+
+// SetOlderThanDays sets the value of OlderThanDays.
+func (s *ApplyRetentionRequest) SetOlderThanDays(val int) {
+	s.OlderThanDays = val
+}
+
+// SetMode sets the value of Mode.
+func (s *ApplyRetentionRequest) SetMode(val OptApplyRetentionRequestMode) {
+	s.Mode = val
+}
+
+type ApplyRetentionRequestMode string
+
+const (
+	ApplyRetentionRequestModePurge   ApplyRetentionRequestMode = "purge"
+	ApplyRetentionRequestModeArchive ApplyRetentionRequestMode = "archive"
+)
+
+// AllValues returns all ApplyRetentionRequestMode values.
+func (ApplyRetentionRequestMode) AllValues() []ApplyRetentionRequestMode {
+	return []ApplyRetentionRequestMode{
+		ApplyRetentionRequestModePurge,
+		ApplyRetentionRequestModeArchive,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ApplyRetentionRequestMode) MarshalText() ([]byte, error) {
+	switch s {
+	case ApplyRetentionRequestModePurge:
+		return []byte(s), nil
+	case ApplyRetentionRequestModeArchive:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ApplyRetentionRequestMode) UnmarshalText(data []byte) error {
+	switch ApplyRetentionRequestMode(data) {
+	case ApplyRetentionRequestModePurge:
+		*s = ApplyRetentionRequestModePurge
+		return nil
+	case ApplyRetentionRequestModeArchive:
+		*s = ApplyRetentionRequestModeArchive
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ApplyRetentionResponse
+type ApplyRetentionResponse struct {
+	Success        bool      `json:"success"`
+	Mode           string    `json:"mode"`
+	Before         time.Time `json:"before"`
+	OrdersAffected int       `json:"orders_affected"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *ApplyRetentionResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetMode returns the value of Mode.
+func (s *ApplyRetentionResponse) GetMode() string {
+	return s.Mode
+}
+
+// GetBefore returns the value of Before.
+func (s *ApplyRetentionResponse) GetBefore() time.Time {
+	return s.Before
+}
+
+// GetOrdersAffected returns the value of OrdersAffected.
+func (s *ApplyRetentionResponse) GetOrdersAffected() int {
+	return s.OrdersAffected
+}
+
+// SetSuccess sets the value of Success.
+func (s *ApplyRetentionResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetMode sets the value of Mode.
+func (s *ApplyRetentionResponse) SetMode(val string) {
+	s.Mode = val
+}
+
+// SetBefore sets the value of Before.
+func (s *ApplyRetentionResponse) SetBefore(val time.Time) {
+	s.Before = val
+}
+
+// SetOrdersAffected sets the value of OrdersAffected.
+func (s *ApplyRetentionResponse) SetOrdersAffected(val int) {
+	s.OrdersAffected = val
+}
+
+// Ref: #/components/schemas/BrandCount
+type BrandCount struct {
+	Brand string `json:"brand"`
+	Items int    `json:"items"`
+}
+
+// GetBrand returns the value of Brand.
+func (s *BrandCount) GetBrand() string {
+	return s.Brand
+}
+
+// GetItems returns the value of Items.
+func (s *BrandCount) GetItems() int {
+	return s.Items
+}
+
+// SetBrand sets the value of Brand.
+func (s *BrandCount) SetBrand(val string) {
+	s.Brand = val
+}
+
+// SetItems sets the value of Items.
+func (s *BrandCount) SetItems(val int) {
+	s.Items = val
+}
+
+// Ref: #/components/schemas/BrandSales
+type BrandSales struct {
+	Currency string `json:"currency"`
+	Brand    string `json:"brand"`
+	Items    int64  `json:"items"`
+	Revenue  int64  `json:"revenue"`
+}
+
+// GetCurrency returns the value of Currency.
+func (s *BrandSales) GetCurrency() string {
+	return s.Currency
+}
+
+// GetBrand returns the value of Brand.
+func (s *BrandSales) GetBrand() string {
+	return s.Brand
+}
+
+// GetItems returns the value of Items.
+func (s *BrandSales) GetItems() int64 {
+	return s.Items
+}
+
+// GetRevenue returns the value of Revenue.
+func (s *BrandSales) GetRevenue() int64 {
+	return s.Revenue
+}
+
+// SetCurrency sets the value of Currency.
+func (s *BrandSales) SetCurrency(val string) {
+	s.Currency = val
+}
+
+// SetBrand sets the value of Brand.
+func (s *BrandSales) SetBrand(val string) {
+	s.Brand = val
+}
+
+// SetItems sets the value of Items.
+func (s *BrandSales) SetItems(val int64) {
+	s.Items = val
+}
+
+// SetRevenue sets the value of Revenue.
+func (s *BrandSales) SetRevenue(val int64) {
+	s.Revenue = val
+}
+
+// Ref: #/components/schemas/ConsumerFlowStatus
+type ConsumerFlowStatus struct {
+	Paused       bool                      `json:"paused"`
+	ManualPause  bool                      `json:"manual_pause"`
+	PausedAt     OptDateTime               `json:"paused_at"`
+	Breaker      ConsumerFlowStatusBreaker `json:"breaker"`
+	BreakerCause OptString                 `json:"breaker_cause"`
+	RetryAt      OptDateTime               `json:"retry_at"`
+	// Сообщений в секунду, 0 - без ограничения.
+	MaxRate float64 `json:"max_rate"`
+	// Емкость корзины ограничения скорости.
+	Burst int `json:"burst"`
+}
+
+// GetPaused returns the value of Paused.
+func (s *ConsumerFlowStatus) GetPaused() bool {
+	return s.Paused
+}
+
+// GetManualPause returns the value of ManualPause.
+func (s *ConsumerFlowStatus) GetManualPause() bool {
+	return s.ManualPause
+}
+
+// GetPausedAt returns the value of PausedAt.
+func (s *ConsumerFlowStatus) GetPausedAt() OptDateTime {
+	return s.PausedAt
+}
+
+// GetBreaker returns the value of Breaker.
+func (s *ConsumerFlowStatus) GetBreaker() ConsumerFlowStatusBreaker {
+	return s.Breaker
+}
+
+// GetBreakerCause returns the value of BreakerCause.
+func (s *ConsumerFlowStatus) GetBreakerCause() OptString {
+	return s.BreakerCause
+}
+
+// GetRetryAt returns the value of RetryAt.
+func (s *ConsumerFlowStatus) GetRetryAt() OptDateTime {
+	return s.RetryAt
+}
+
+// GetMaxRate returns the value of MaxRate.
+func (s *ConsumerFlowStatus) GetMaxRate() float64 {
+	return s.MaxRate
+}
+
+// GetBurst returns the value of Burst.
+func (s *ConsumerFlowStatus) GetBurst() int {
+	return s.Burst
+}
+
+// SetPaused sets the value of Paused.
+func (s *ConsumerFlowStatus) SetPaused(val bool) {
+	s.Paused = val
+}
+
+// SetManualPause sets the value of ManualPause.
+func (s *ConsumerFlowStatus) SetManualPause(val bool) {
+	s.ManualPause = val
+}
+
+// SetPausedAt sets the value of PausedAt.
+func (s *ConsumerFlowStatus) SetPausedAt(val OptDateTime) {
+	s.PausedAt = val
+}
+
+// SetBreaker sets the value of Breaker.
+func (s *ConsumerFlowStatus) SetBreaker(val ConsumerFlowStatusBreaker) {
+	s.Breaker = val
+}
+
+// SetBreakerCause sets the value of BreakerCause.
+func (s *ConsumerFlowStatus) SetBreakerCause(val OptString) {
+	s.BreakerCause = val
+}
+
+// SetRetryAt sets the value of RetryAt.
+func (s *ConsumerFlowStatus) SetRetryAt(val OptDateTime) {
+	s.RetryAt = val
+}
+
+// SetMaxRate sets the value of MaxRate.
+func (s *ConsumerFlowStatus) SetMaxRate(val float64) {
+	s.MaxRate = val
+}
+
+// SetBurst sets the value of Burst.
+func (s *ConsumerFlowStatus) SetBurst(val int) {
+	s.Burst = val
+}
+
+type ConsumerFlowStatusBreaker string
+
+const (
+	ConsumerFlowStatusBreakerClosed   ConsumerFlowStatusBreaker = "closed"
+	ConsumerFlowStatusBreakerOpen     ConsumerFlowStatusBreaker = "open"
+	ConsumerFlowStatusBreakerHalfOpen ConsumerFlowStatusBreaker = "half-open"
+)
+
+// AllValues returns all ConsumerFlowStatusBreaker values.
+func (ConsumerFlowStatusBreaker) AllValues() []ConsumerFlowStatusBreaker {
+	return []ConsumerFlowStatusBreaker{
+		ConsumerFlowStatusBreakerClosed,
+		ConsumerFlowStatusBreakerOpen,
+		ConsumerFlowStatusBreakerHalfOpen,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ConsumerFlowStatusBreaker) MarshalText() ([]byte, error) {
+	switch s {
+	case ConsumerFlowStatusBreakerClosed:
+		return []byte(s), nil
+	case ConsumerFlowStatusBreakerOpen:
+		return []byte(s), nil
+	case ConsumerFlowStatusBreakerHalfOpen:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ConsumerFlowStatusBreaker) UnmarshalText(data []byte) error {
+	switch ConsumerFlowStatusBreaker(data) {
+	case ConsumerFlowStatusBreakerClosed:
+		*s = ConsumerFlowStatusBreakerClosed
+		return nil
+	case ConsumerFlowStatusBreakerOpen:
+		*s = ConsumerFlowStatusBreakerOpen
+		return nil
+	case ConsumerFlowStatusBreakerHalfOpen:
+		*s = ConsumerFlowStatusBreakerHalfOpen
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ConsumerGroupMember
+type ConsumerGroupMember struct {
+	MemberID   string `json:"member_id"`
+	ClientID   string `json:"client_id"`
+	ClientHost string `json:"client_host"`
+	// Участник - этот экземпляр.
+	Local bool `json:"local"`
+	// Назначенные партиции по топикам.
+	Partitions ConsumerGroupMemberPartitions `json:"partitions"`
+}
+
+// GetMemberID returns the value of MemberID.
+func (s *ConsumerGroupMember) GetMemberID() string {
+	return s.MemberID
+}
+
+// GetClientID returns the value of ClientID.
+func (s *ConsumerGroupMember) GetClientID() string {
+	return s.ClientID
+}
+
+// GetClientHost returns the value of ClientHost.
+func (s *ConsumerGroupMember) GetClientHost() string {
+	return s.ClientHost
+}
+
+// GetLocal returns the value of Local.
+func (s *ConsumerGroupMember) GetLocal() bool {
+	return s.Local
+}
+
+// GetPartitions returns the value of Partitions.
+func (s *ConsumerGroupMember) GetPartitions() ConsumerGroupMemberPartitions {
+	return s.Partitions
+}
+
+// SetMemberID sets the value of MemberID.
+func (s *ConsumerGroupMember) SetMemberID(val string) {
+	s.MemberID = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *ConsumerGroupMember) SetClientID(val string) {
+	s.ClientID = val
+}
+
+// SetClientHost sets the value of ClientHost.
+func (s *ConsumerGroupMember) SetClientHost(val string) {
+	s.ClientHost = val
+}
+
+// SetLocal sets the value of Local.
+func (s *ConsumerGroupMember) SetLocal(val bool) {
+	s.Local = val
+}
+
+// SetPartitions sets the value of Partitions.
+func (s *ConsumerGroupMember) SetPartitions(val ConsumerGroupMemberPartitions) {
+	s.Partitions = val
+}
+
+// Назначенные партиции по топикам.
+type ConsumerGroupMemberPartitions map[string][]int
+
+func (s *ConsumerGroupMemberPartitions) init() ConsumerGroupMemberPartitions {
+	m := *s
+	if m == nil {
+		m = map[string][]int{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/ConsumerPartitionStatus
+type ConsumerPartitionStatus struct {
+	Topic     string    `json:"topic"`
+	Partition int       `json:"partition"`
+	MemberID  OptString `json:"member_id"`
+	Local     bool      `json:"local"`
+	// -1 - оффсет не закоммичен.
+	CommittedOffset int64       `json:"committed_offset"`
+	HighWatermark   int64       `json:"high_watermark"`
+	Lag             int64       `json:"lag"`
+	LastOffset      OptInt64    `json:"last_offset"`
+	LastProcessedAt OptDateTime `json:"last_processed_at"`
+	Processed       int64       `json:"processed"`
+	// Ошибки обработки по причинам.
+	Errors OptConsumerPartitionStatusErrors `json:"errors"`
+}
+
+// GetTopic returns the value of Topic.
+func (s *ConsumerPartitionStatus) GetTopic() string {
+	return s.Topic
+}
+
+// GetPartition returns the value of Partition.
+func (s *ConsumerPartitionStatus) GetPartition() int {
+	return s.Partition
+}
+
+// GetMemberID returns the value of MemberID.
+func (s *ConsumerPartitionStatus) GetMemberID() OptString {
+	return s.MemberID
+}
+
+// GetLocal returns the value of Local.
+func (s *ConsumerPartitionStatus) GetLocal() bool {
+	return s.Local
+}
+
+// GetCommittedOffset returns the value of CommittedOffset.
+func (s *ConsumerPartitionStatus) GetCommittedOffset() int64 {
+	return s.CommittedOffset
+}
+
+// GetHighWatermark returns the value of HighWatermark.
+func (s *ConsumerPartitionStatus) GetHighWatermark() int64 {
+	return s.HighWatermark
+}
+
+// GetLag returns the value of Lag.
+func (s *ConsumerPartitionStatus) GetLag() int64 {
+	return s.Lag
+}
+
+// GetLastOffset returns the value of LastOffset.
+func (s *ConsumerPartitionStatus) GetLastOffset() OptInt64 {
+	return s.LastOffset
+}
+
+// GetLastProcessedAt returns the value of LastProcessedAt.
+func (s *ConsumerPartitionStatus) GetLastProcessedAt() OptDateTime {
+	return s.LastProcessedAt
+}
+
+// GetProcessed returns the value of Processed.
+func (s *ConsumerPartitionStatus) GetProcessed() int64 {
+	return s.Processed
+}
+
+// GetErrors returns the value of Errors.
+func (s *ConsumerPartitionStatus) GetErrors() OptConsumerPartitionStatusErrors {
+	return s.Errors
+}
+
+// SetTopic sets the value of Topic.
+func (s *ConsumerPartitionStatus) SetTopic(val string) {
+	s.Topic = val
+}
+
+// SetPartition sets the value of Partition.
+func (s *ConsumerPartitionStatus) SetPartition(val int) {
+	s.Partition = val
+}
+
+// SetMemberID sets the value of MemberID.
+func (s *ConsumerPartitionStatus) SetMemberID(val OptString) {
+	s.MemberID = val
+}
+
+// SetLocal sets the value of Local.
+func (s *ConsumerPartitionStatus) SetLocal(val bool) {
+	s.Local = val
+}
+
+// SetCommittedOffset sets the value of CommittedOffset.
+func (s *ConsumerPartitionStatus) SetCommittedOffset(val int64) {
+	s.CommittedOffset = val
+}
+
+// SetHighWatermark sets the value of HighWatermark.
+func (s *ConsumerPartitionStatus) SetHighWatermark(val int64) {
+	s.HighWatermark = val
+}
+
+// SetLag sets the value of Lag.
+func (s *ConsumerPartitionStatus) SetLag(val int64) {
+	s.Lag = val
+}
+
+// SetLastOffset sets the value of LastOffset.
+func (s *ConsumerPartitionStatus) SetLastOffset(val OptInt64) {
+	s.LastOffset = val
+}
+
+// SetLastProcessedAt sets the value of LastProcessedAt.
+func (s *ConsumerPartitionStatus) SetLastProcessedAt(val OptDateTime) {
+	s.LastProcessedAt = val
+}
+
+// SetProcessed sets the value of Processed.
+func (s *ConsumerPartitionStatus) SetProcessed(val int64) {
+	s.Processed = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *ConsumerPartitionStatus) SetErrors(val OptConsumerPartitionStatusErrors) {
+	s.Errors = val
+}
+
+// Ошибки обработки по причинам.
+type ConsumerPartitionStatusErrors map[string]int64
+
+func (s *ConsumerPartitionStatusErrors) init() ConsumerPartitionStatusErrors {
+	m := *s
+	if m == nil {
+		m = map[string]int64{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/ConsumerRateRequest
+type ConsumerRateRequest struct {
+	// Сообщений в секунду, 0 снимает ограничение.
+	MaxRate float64 `json:"max_rate"`
+	// Емкость корзины, без burst остается текущая.
+	Burst OptInt `json:"burst"`
+}
+
+// GetMaxRate returns the value of MaxRate.
+func (s *ConsumerRateRequest) GetMaxRate() float64 {
+	return s.MaxRate
+}
+
+// GetBurst returns the value of Burst.
+func (s *ConsumerRateRequest) GetBurst() OptInt {
+	return s.Burst
+}
+
+// SetMaxRate sets the value of MaxRate.
+func (s *ConsumerRateRequest) SetMaxRate(val float64) {
+	s.MaxRate = val
+}
+
+// SetBurst sets the value of Burst.
+func (s *ConsumerRateRequest) SetBurst(val OptInt) {
+	s.Burst = val
+}
+
+// Ref: #/components/schemas/ConsumerReaderStatus
+type ConsumerReaderStatus struct {
+	Messages      int64 `json:"messages"`
+	Bytes         int64 `json:"bytes"`
+	Fetches       int64 `json:"fetches"`
+	Dials         int64 `json:"dials"`
+	Rebalances    int64 `json:"rebalances"`
+	Timeouts      int64 `json:"timeouts"`
+	Errors        int64 `json:"errors"`
+	ReadErrors    int64 `json:"read_errors"`
+	QueueLength   int64 `json:"queue_length"`
+	QueueCapacity int64 `json:"queue_capacity"`
+}
+
+// GetMessages returns the value of Messages.
+func (s *ConsumerReaderStatus) GetMessages() int64 {
+	return s.Messages
+}
+
+// GetBytes returns the value of Bytes.
+func (s *ConsumerReaderStatus) GetBytes() int64 {
+	return s.Bytes
+}
+
+// GetFetches returns the value of Fetches.
+func (s *ConsumerReaderStatus) GetFetches() int64 {
+	return s.Fetches
+}
+
+// GetDials returns the value of Dials.
+func (s *ConsumerReaderStatus) GetDials() int64 {
+	return s.Dials
+}
+
+// GetRebalances returns the value of Rebalances.
+func (s *ConsumerReaderStatus) GetRebalances() int64 {
+	return s.Rebalances
+}
+
+// GetTimeouts returns the value of Timeouts.
+func (s *ConsumerReaderStatus) GetTimeouts() int64 {
+	return s.Timeouts
+}
+
+// GetErrors returns the value of Errors.
+func (s *ConsumerReaderStatus) GetErrors() int64 {
+	return s.Errors
+}
+
+// GetReadErrors returns the value of ReadErrors.
+func (s *ConsumerReaderStatus) GetReadErrors() int64 {
+	return s.ReadErrors
+}
+
+// GetQueueLength returns the value of QueueLength.
+func (s *ConsumerReaderStatus) GetQueueLength() int64 {
+	return s.QueueLength
+}
+
+// GetQueueCapacity returns the value of QueueCapacity.
+func (s *ConsumerReaderStatus) GetQueueCapacity() int64 {
+	return s.QueueCapacity
+}
+
+// SetMessages sets the value of Messages.
+func (s *ConsumerReaderStatus) SetMessages(val int64) {
+	s.Messages = val
+}
+
+// SetBytes sets the value of Bytes.
+func (s *ConsumerReaderStatus) SetBytes(val int64) {
+	s.Bytes = val
+}
+
+// SetFetches sets the value of Fetches.
+func (s *ConsumerReaderStatus) SetFetches(val int64) {
+	s.Fetches = val
+}
+
+// SetDials sets the value of Dials.
+func (s *ConsumerReaderStatus) SetDials(val int64) {
+	s.Dials = val
+}
+
+// SetRebalances sets the value of Rebalances.
+func (s *ConsumerReaderStatus) SetRebalances(val int64) {
+	s.Rebalances = val
+}
+
+// SetTimeouts sets the value of Timeouts.
+func (s *ConsumerReaderStatus) SetTimeouts(val int64) {
+	s.Timeouts = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *ConsumerReaderStatus) SetErrors(val int64) {
+	s.Errors = val
+}
+
+// SetReadErrors sets the value of ReadErrors.
+func (s *ConsumerReaderStatus) SetReadErrors(val int64) {
+	s.ReadErrors = val
+}
+
+// SetQueueLength sets the value of QueueLength.
+func (s *ConsumerReaderStatus) SetQueueLength(val int64) {
+	s.QueueLength = val
+}
+
+// SetQueueCapacity sets the value of QueueCapacity.
+func (s *ConsumerReaderStatus) SetQueueCapacity(val int64) {
+	s.QueueCapacity = val
+}
+
+// Ref: #/components/schemas/ConsumerReplayError
+type ConsumerReplayError struct {
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	Reason    string `json:"reason"`
+	Error     string `json:"error"`
+}
+
+// GetPartition returns the value of Partition.
+func (s *ConsumerReplayError) GetPartition() int {
+	return s.Partition
+}
+
+// GetOffset returns the value of Offset.
+func (s *ConsumerReplayError) GetOffset() int64 {
+	return s.Offset
+}
+
+// GetReason returns the value of Reason.
+func (s *ConsumerReplayError) GetReason() string {
+	return s.Reason
+}
+
+// GetError returns the value of Error.
+func (s *ConsumerReplayError) GetError() string {
+	return s.Error
+}
+
+// SetPartition sets the value of Partition.
+func (s *ConsumerReplayError) SetPartition(val int) {
+	s.Partition = val
+}
+
+// SetOffset sets the value of Offset.
+func (s *ConsumerReplayError) SetOffset(val int64) {
+	s.Offset = val
+}
+
+// SetReason sets the value of Reason.
+func (s *ConsumerReplayError) SetReason(val string) {
+	s.Reason = val
+}
+
+// SetError sets the value of Error.
+func (s *ConsumerReplayError) SetError(val string) {
+	s.Error = val
+}
+
+// Ref: #/components/schemas/ConsumerReplayFailure
+type ConsumerReplayFailure struct {
+	Error  string               `json:"error"`
+	Report ConsumerReplayReport `json:"report"`
+}
+
+// GetError returns the value of Error.
+func (s *ConsumerReplayFailure) GetError() string {
+	return s.Error
+}
+
+// GetReport returns the value of Report.
+func (s *ConsumerReplayFailure) GetReport() ConsumerReplayReport {
+	return s.Report
+}
+
+// SetError sets the value of Error.
+func (s *ConsumerReplayFailure) SetError(val string) {
+	s.Error = val
+}
+
+// SetReport sets the value of Report.
+func (s *ConsumerReplayFailure) SetReport(val ConsumerReplayReport) {
+	s.Report = val
+}
+
+func (*ConsumerReplayFailure) replayConsumerRes() {}
+
+// Ref: #/components/schemas/ConsumerReplayPartition
+type ConsumerReplayPartition struct {
+	Partition  int   `json:"partition"`
+	From       int64 `json:"from"`
+	To         int64 `json:"to"`
+	Read       int   `json:"read"`
+	NextOffset int64 `json:"next_offset"`
+}
+
+// GetPartition returns the value of Partition.
+func (s *ConsumerReplayPartition) GetPartition() int {
+	return s.Partition
+}
+
+// GetFrom returns the value of From.
+func (s *ConsumerReplayPartition) GetFrom() int64 {
+	return s.From
+}
+
+// GetTo returns the value of To.
+func (s *ConsumerReplayPartition) GetTo() int64 {
+	return s.To
+}
+
+// GetRead returns the value of Read.
+func (s *ConsumerReplayPartition) GetRead() int {
+	return s.Read
+}
+
+// GetNextOffset returns the value of NextOffset.
+func (s *ConsumerReplayPartition) GetNextOffset() int64 {
+	return s.NextOffset
+}
+
+// SetPartition sets the value of Partition.
+func (s *ConsumerReplayPartition) SetPartition(val int) {
+	s.Partition = val
+}
+
+// SetFrom sets the value of From.
+func (s *ConsumerReplayPartition) SetFrom(val int64) {
+	s.From = val
+}
+
+// SetTo sets the value of To.
+func (s *ConsumerReplayPartition) SetTo(val int64) {
+	s.To = val
+}
+
+// SetRead sets the value of Read.
+func (s *ConsumerReplayPartition) SetRead(val int) {
+	s.Read = val
+}
+
+// SetNextOffset sets the value of NextOffset.
+func (s *ConsumerReplayPartition) SetNextOffset(val int64) {
+	s.NextOffset = val
+}
+
+// Ref: #/components/schemas/ConsumerReplayReport
+type ConsumerReplayReport struct {
+	Topic      string                    `json:"topic"`
+	Handler    string                    `json:"handler"`
+	Mode       string                    `json:"mode"`
+	Partitions []ConsumerReplayPartition `json:"partitions"`
+	Read       int                       `json:"read"`
+	Valid      int                       `json:"valid"`
+	Invalid    int                       `json:"invalid"`
+	Applied    int                       `json:"applied"`
+	Failed     int                       `json:"failed"`
+	// Чтение остановлено по limit.
+	Truncated bool `json:"truncated"`
+	// Первые 100 ошибок.
+	Errors []ConsumerReplayError `json:"errors"`
+}
+
+// GetTopic returns the value of Topic.
+func (s *ConsumerReplayReport) GetTopic() string {
+	return s.Topic
+}
+
+// GetHandler returns the value of Handler.
+func (s *ConsumerReplayReport) GetHandler() string {
+	return s.Handler
+}
+
+// GetMode returns the value of Mode.
+func (s *ConsumerReplayReport) GetMode() string {
+	return s.Mode
+}
+
+// GetPartitions returns the value of Partitions.
+func (s *ConsumerReplayReport) GetPartitions() []ConsumerReplayPartition {
+	return s.Partitions
+}
+
+// GetRead returns the value of Read.
+func (s *ConsumerReplayReport) GetRead() int {
+	return s.Read
+}
+
+// GetValid returns the value of Valid.
+func (s *ConsumerReplayReport) GetValid() int {
+	return s.Valid
+}
+
+// GetInvalid returns the value of Invalid.
+func (s *ConsumerReplayReport) GetInvalid() int {
+	return s.Invalid
+}
+
+// GetApplied returns the value of Applied.
+func (s *ConsumerReplayReport) GetApplied() int {
+	return s.Applied
+}
+
+// GetFailed returns the value of Failed.
+func (s *ConsumerReplayReport) GetFailed() int {
+	return s.Failed
+}
+
+// GetTruncated returns the value of Truncated.
+func (s *ConsumerReplayReport) GetTruncated() bool {
+	return s.Truncated
+}
+
+// GetErrors returns the value of Errors.
+func (s *ConsumerReplayReport) GetErrors() []ConsumerReplayError {
+	return s.Errors
+}
+
+// SetTopic sets the value of Topic.
+func (s *ConsumerReplayReport) SetTopic(val string) {
+	s.Topic = val
+}
+
+// SetHandler sets the value of Handler.
+func (s *ConsumerReplayReport) SetHandler(val string) {
+	s.Handler = val
+}
+
+// SetMode sets the value of Mode.
+func (s *ConsumerReplayReport) SetMode(val string) {
+	s.Mode = val
+}
+
+// SetPartitions sets the value of Partitions.
+func (s *ConsumerReplayReport) SetPartitions(val []ConsumerReplayPartition) {
+	s.Partitions = val
+}
+
+// SetRead sets the value of Read.
+func (s *ConsumerReplayReport) SetRead(val int) {
+	s.Read = val
+}
+
+// SetValid sets the value of Valid.
+func (s *ConsumerReplayReport) SetValid(val int) {
+	s.Valid = val
+}
+
+// SetInvalid sets the value of Invalid.
+func (s *ConsumerReplayReport) SetInvalid(val int) {
+	s.Invalid = val
+}
+
+// SetApplied sets the value of Applied.
+func (s *ConsumerReplayReport) SetApplied(val int) {
+	s.Applied = val
+}
+
+// SetFailed sets the value of Failed.
+func (s *ConsumerReplayReport) SetFailed(val int) {
+	s.Failed = val
+}
+
+// SetTruncated sets the value of Truncated.
+func (s *ConsumerReplayReport) SetTruncated(val bool) {
+	s.Truncated = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *ConsumerReplayReport) SetErrors(val []ConsumerReplayError) {
+	s.Errors = val
+}
+
+func (*ConsumerReplayReport) replayConsumerRes() {}
+
+// Ref: #/components/schemas/ConsumerReplayRequest
+type ConsumerReplayRequest struct {
+	// Без topic - топик консьюмера, если он читает один топик.
+	Topic OptString `json:"topic"`
+	// Без partitions - все партиции топика.
+	Partitions []int `json:"partitions"`
+	// Earliest, latest, номер оффсета или время RFC3339.
+	From OptString `json:"from"`
+	// Конец диапазона (не включается), формат как у from.
+	To   OptString                    `json:"to"`
+	Mode OptConsumerReplayRequestMode `json:"mode"`
+	// Максимум сообщений за запрос; при truncated следующий
+	// запрос начинается с next_offset партиций.
+	Limit OptInt `json:"limit"`
+}
+
+// GetTopic returns the value of Topic.
+func (s *ConsumerReplayRequest) GetTopic() OptString {
+	return s.Topic
+}
+
+// GetPartitions returns the value of Partitions.
+func (s *ConsumerReplayRequest) GetPartitions() []int {
+	return s.Partitions
+}
+
+// GetFrom returns the value of From.
+func (s *ConsumerReplayRequest) GetFrom() OptString {
+	return s.From
+}
+
+// GetTo returns the value of To.
+func (s *ConsumerReplayRequest) GetTo() OptString {
+	return s.To
+}
+
+// GetMode returns the value of Mode.
+func (s *ConsumerReplayRequest) GetMode() OptConsumerReplayRequestMode {
+	return s.Mode
+}
+
+// GetLimit returns the value of Limit.
+func (s *ConsumerReplayRequest) GetLimit() OptInt {
+	return s.Limit
+}
+
+// SetTopic sets the value of Topic.
+func (s *ConsumerReplayRequest) SetTopic(val OptString) {
+	s.Topic = val
+}
+
+// SetPartitions sets the value of Partitions.
+func (s *ConsumerReplayRequest) SetPartitions(val []int) {
+	s.Partitions = val
+}
+
+// SetFrom sets the value of From.
+func (s *ConsumerReplayRequest) SetFrom(val OptString) {
+	s.From = val
+}
+
+// SetTo sets the value of To.
+func (s *ConsumerReplayRequest) SetTo(val OptString) {
+	s.To = val
+}
+
+// SetMode sets the value of Mode.
+func (s *ConsumerReplayRequest) SetMode(val OptConsumerReplayRequestMode) {
+	s.Mode = val
+}
+
+// SetLimit sets the value of Limit.
+func (s *ConsumerReplayRequest) SetLimit(val OptInt) {
+	s.Limit = val
+}
+
+type ConsumerReplayRequestMode string
+
+const (
+	ConsumerReplayRequestModeDryRun   ConsumerReplayRequestMode = "dry-run"
+	ConsumerReplayRequestModeValidate ConsumerReplayRequestMode = "validate"
+	ConsumerReplayRequestModeApply    ConsumerReplayRequestMode = "apply"
+)
+
+// AllValues returns all ConsumerReplayRequestMode values.
+func (ConsumerReplayRequestMode) AllValues() []ConsumerReplayRequestMode {
+	return []ConsumerReplayRequestMode{
+		ConsumerReplayRequestModeDryRun,
+		ConsumerReplayRequestModeValidate,
+		ConsumerReplayRequestModeApply,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ConsumerReplayRequestMode) MarshalText() ([]byte, error) {
+	switch s {
+	case ConsumerReplayRequestModeDryRun:
+		return []byte(s), nil
+	case ConsumerReplayRequestModeValidate:
+		return []byte(s), nil
+	case ConsumerReplayRequestModeApply:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ConsumerReplayRequestMode) UnmarshalText(data []byte) error {
+	switch ConsumerReplayRequestMode(data) {
+	case ConsumerReplayRequestModeDryRun:
+		*s = ConsumerReplayRequestModeDryRun
+		return nil
+	case ConsumerReplayRequestModeValidate:
+		*s = ConsumerReplayRequestModeValidate
+		return nil
+	case ConsumerReplayRequestModeApply:
+		*s = ConsumerReplayRequestModeApply
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ConsumerStatus
+type ConsumerStatus struct {
+	GroupID    string                    `json:"group_id"`
+	GroupState OptString                 `json:"group_state"`
+	ClientID   string                    `json:"client_id"`
+	Topics     []string                  `json:"topics"`
+	Members    []ConsumerGroupMember     `json:"members"`
+	Partitions []ConsumerPartitionStatus `json:"partitions"`
+	TotalLag   int64                     `json:"total_lag"`
+	Reader     ConsumerReaderStatus      `json:"reader"`
+	Flow       ConsumerFlowStatus        `json:"flow"`
+	// Ошибки запросов к брокерам, отчет при этом может быть
+	// неполным.
+	Errors []string `json:"errors"`
+}
+
+// GetGroupID returns the value of GroupID.
+func (s *ConsumerStatus) GetGroupID() string {
+	return s.GroupID
+}
+
+// GetGroupState returns the value of GroupState.
+func (s *ConsumerStatus) GetGroupState() OptString {
+	return s.GroupState
+}
+
+// GetClientID returns the value of ClientID.
+func (s *ConsumerStatus) GetClientID() string {
+	return s.ClientID
+}
+
+// GetTopics returns the value of Topics.
+func (s *ConsumerStatus) GetTopics() []string {
+	return s.Topics
+}
+
+// GetMembers returns the value of Members.
+func (s *ConsumerStatus) GetMembers() []ConsumerGroupMember {
+	return s.Members
+}
+
+// GetPartitions returns the value of Partitions.
+func (s *ConsumerStatus) GetPartitions() []ConsumerPartitionStatus {
+	return s.Partitions
+}
+
+// GetTotalLag returns the value of TotalLag.
+func (s *ConsumerStatus) GetTotalLag() int64 {
+	return s.TotalLag
+}
+
+// GetReader returns the value of Reader.
+func (s *ConsumerStatus) GetReader() ConsumerReaderStatus {
+	return s.Reader
+}
+
+// GetFlow returns the value of Flow.
+func (s *ConsumerStatus) GetFlow() ConsumerFlowStatus {
+	return s.Flow
+}
+
+// GetErrors returns the value of Errors.
+func (s *ConsumerStatus) GetErrors() []string {
+	return s.Errors
+}
+
+// SetGroupID sets the value of GroupID.
+func (s *ConsumerStatus) SetGroupID(val string) {
+	s.GroupID = val
+}
+
+// SetGroupState sets the value of GroupState.
+func (s *ConsumerStatus) SetGroupState(val OptString) {
+	s.GroupState = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *ConsumerStatus) SetClientID(val string) {
+	s.ClientID = val
+}
+
+// SetTopics sets the value of Topics.
+func (s *ConsumerStatus) SetTopics(val []string) {
+	s.Topics = val
+}
+
+// SetMembers sets the value of Members.
+func (s *ConsumerStatus) SetMembers(val []ConsumerGroupMember) {
+	s.Members = val
+}
+
+// SetPartitions sets the value of Partitions.
+func (s *ConsumerStatus) SetPartitions(val []ConsumerPartitionStatus) {
+	s.Partitions = val
+}
+
+// SetTotalLag sets the value of TotalLag.
+func (s *ConsumerStatus) SetTotalLag(val int64) {
+	s.TotalLag = val
+}
+
+// SetReader sets the value of Reader.
+func (s *ConsumerStatus) SetReader(val ConsumerReaderStatus) {
+	s.Reader = val
+}
+
+// SetFlow sets the value of Flow.
+func (s *ConsumerStatus) SetFlow(val ConsumerFlowStatus) {
+	s.Flow = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *ConsumerStatus) SetErrors(val []string) {
+	s.Errors = val
+}
+
+// Ref: #/components/schemas/Conversion
+type Conversion struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Rate float64 `json:"rate"`
+}
+
+// GetFrom returns the value of From.
+func (s *Conversion) GetFrom() string {
+	return s.From
+}
+
+// GetTo returns the value of To.
+func (s *Conversion) GetTo() string {
+	return s.To
+}
+
+// GetRate returns the value of Rate.
+func (s *Conversion) GetRate() float64 {
+	return s.Rate
+}
+
+// SetFrom sets the value of From.
+func (s *Conversion) SetFrom(val string) {
+	s.From = val
+}
+
+// SetTo sets the value of To.
+func (s *Conversion) SetTo(val string) {
+	s.To = val
+}
+
+// SetRate sets the value of Rate.
+func (s *Conversion) SetRate(val float64) {
+	s.Rate = val
+}
+
+// Ref: #/components/schemas/CurrencySpend
+type CurrencySpend struct {
+	Currency string `json:"currency"`
+	Total    int64  `json:"total"`
+	Orders   int    `json:"orders"`
+}
+
+// GetCurrency returns the value of Currency.
+func (s *CurrencySpend) GetCurrency() string {
+	return s.Currency
+}
+
+// GetTotal returns the value of Total.
+func (s *CurrencySpend) GetTotal() int64 {
+	return s.Total
+}
+
+// GetOrders returns the value of Orders.
+func (s *CurrencySpend) GetOrders() int {
+	return s.Orders
+}
+
+// SetCurrency sets the value of Currency.
+func (s *CurrencySpend) SetCurrency(val string) {
+	s.Currency = val
+}
+
+// SetTotal sets the value of Total.
+func (s *CurrencySpend) SetTotal(val int64) {
+	s.Total = val
+}
+
+// SetOrders sets the value of Orders.
+func (s *CurrencySpend) SetOrders(val int) {
+	s.Orders = val
+}
+
+// Ref: #/components/schemas/CustomerOrdersResponse
+type CustomerOrdersResponse struct {
+	Success    bool    `json:"success"`
+	CustomerID string  `json:"customer_id"`
+	Orders     []Order `json:"orders"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *CustomerOrdersResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetCustomerID returns the value of CustomerID.
+func (s *CustomerOrdersResponse) GetCustomerID() string {
+	return s.CustomerID
+}
+
+// GetOrders returns the value of Orders.
+func (s *CustomerOrdersResponse) GetOrders() []Order {
+	return s.Orders
+}
+
+// SetSuccess sets the value of Success.
+func (s *CustomerOrdersResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetCustomerID sets the value of CustomerID.
+func (s *CustomerOrdersResponse) SetCustomerID(val string) {
+	s.CustomerID = val
+}
+
+// SetOrders sets the value of Orders.
+func (s *CustomerOrdersResponse) SetOrders(val []Order) {
+	s.Orders = val
+}
+
+// Ref: #/components/schemas/CustomerSummaryResponse
+type CustomerSummaryResponse struct {
+	Success         bool            `json:"success"`
+	CustomerID      string          `json:"customer_id"`
+	Tenant          string          `json:"tenant"`
+	OrdersCount     int             `json:"orders_count"`
+	Spend           []CurrencySpend `json:"spend"`
+	FirstOrderAt    time.Time       `json:"first_order_at"`
+	LastOrderAt     time.Time       `json:"last_order_at"`
+	FavouriteBrands []BrandCount    `json:"favourite_brands"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *CustomerSummaryResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetCustomerID returns the value of CustomerID.
+func (s *CustomerSummaryResponse) GetCustomerID() string {
+	return s.CustomerID
+}
+
+// GetTenant returns the value of Tenant.
+func (s *CustomerSummaryResponse) GetTenant() string {
+	return s.Tenant
+}
+
+// GetOrdersCount returns the value of OrdersCount.
+func (s *CustomerSummaryResponse) GetOrdersCount() int {
+	return s.OrdersCount
+}
+
+// GetSpend returns the value of Spend.
+func (s *CustomerSummaryResponse) GetSpend() []CurrencySpend {
+	return s.Spend
+}
+
+// GetFirstOrderAt returns the value of FirstOrderAt.
+func (s *CustomerSummaryResponse) GetFirstOrderAt() time.Time {
+	return s.FirstOrderAt
+}
+
+// GetLastOrderAt returns the value of LastOrderAt.
+func (s *CustomerSummaryResponse) GetLastOrderAt() time.Time {
+	return s.LastOrderAt
+}
+
+// GetFavouriteBrands returns the value of FavouriteBrands.
+func (s *CustomerSummaryResponse) GetFavouriteBrands() []BrandCount {
+	return s.FavouriteBrands
+}
+
+// SetSuccess sets the value of Success.
+func (s *CustomerSummaryResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetCustomerID sets the value of CustomerID.
+func (s *CustomerSummaryResponse) SetCustomerID(val string) {
+	s.CustomerID = val
+}
+
+// SetTenant sets the value of Tenant.
+func (s *CustomerSummaryResponse) SetTenant(val string) {
+	s.Tenant = val
+}
+
+// SetOrdersCount sets the value of OrdersCount.
+func (s *CustomerSummaryResponse) SetOrdersCount(val int) {
+	s.OrdersCount = val
+}
+
+// SetSpend sets the value of Spend.
+func (s *CustomerSummaryResponse) SetSpend(val []CurrencySpend) {
+	s.Spend = val
+}
+
+// SetFirstOrderAt sets the value of FirstOrderAt.
+func (s *CustomerSummaryResponse) SetFirstOrderAt(val time.Time) {
+	s.FirstOrderAt = val
+}
+
+// SetLastOrderAt sets the value of LastOrderAt.
+func (s *CustomerSummaryResponse) SetLastOrderAt(val time.Time) {
+	s.LastOrderAt = val
+}
+
+// SetFavouriteBrands sets the value of FavouriteBrands.
+func (s *CustomerSummaryResponse) SetFavouriteBrands(val []BrandCount) {
+	s.FavouriteBrands = val
+}
+
+// Ref: #/components/schemas/DeleteOrderRequest
+type DeleteOrderRequest struct {
+	OrderUID string `json:"order_uid"`
+}
+
+// GetOrderUID returns the value of OrderUID.
+func (s *DeleteOrderRequest) GetOrderUID() string {
+	return s.OrderUID
+}
+
+// SetOrderUID sets the value of OrderUID.
+func (s *DeleteOrderRequest) SetOrderUID(val string) {
+	s.OrderUID = val
+}
+
+// Ref: #/components/schemas/DeleteOrderResponse
+type DeleteOrderResponse struct {
+	Success  bool   `json:"success"`
+	OrderUID string `json:"order_uid"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *DeleteOrderResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetOrderUID returns the value of OrderUID.
+func (s *DeleteOrderResponse) GetOrderUID() string {
+	return s.OrderUID
+}
+
+// SetSuccess sets the value of Success.
+func (s *DeleteOrderResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetOrderUID sets the value of OrderUID.
+func (s *DeleteOrderResponse) SetOrderUID(val string) {
+	s.OrderUID = val
+}
+
+// Ref: #/components/schemas/Delivery
+type Delivery struct {
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Zip     string `json:"zip"`
+	City    string `json:"city"`
+	Address string `json:"address"`
+	Region  string `json:"region"`
+	Email   string `json:"email"`
+}
+
+// GetName returns the value of Name.
+func (s *Delivery) GetName() string {
+	return s.Name
+}
+
+// GetPhone returns the value of Phone.
+func (s *Delivery) GetPhone() string {
+	return s.Phone
+}
+
+// GetZip returns the value of Zip.
+func (s *Delivery) GetZip() string {
+	return s.Zip
+}
+
+// GetCity returns the value of City.
+func (s *Delivery) GetCity() string {
+	return s.City
+}
+
+// GetAddress returns the value of Address.
+func (s *Delivery) GetAddress() string {
+	return s.Address
+}
+
+// GetRegion returns the value of Region.
+func (s *Delivery) GetRegion() string {
+	return s.Region
+}
+
+// GetEmail returns the value of Email.
+func (s *Delivery) GetEmail() string {
+	return s.Email
+}
+
+// SetName sets the value of Name.
+func (s *Delivery) SetName(val string) {
+	s.Name = val
+}
+
+// SetPhone sets the value of Phone.
+func (s *Delivery) SetPhone(val string) {
+	s.Phone = val
+}
+
+// SetZip sets the value of Zip.
+func (s *Delivery) SetZip(val string) {
+	s.Zip = val
+}
+
+// SetCity sets the value of City.
+func (s *Delivery) SetCity(val string) {
+	s.City = val
+}
+
+// SetAddress sets the value of Address.
+func (s *Delivery) SetAddress(val string) {
+	s.Address = val
+}
+
+// SetRegion sets the value of Region.
+func (s *Delivery) SetRegion(val string) {
+	s.Region = val
+}
+
+// SetEmail sets the value of Email.
+func (s *Delivery) SetEmail(val string) {
+	s.Email = val
+}
+
+// Ref: #/components/schemas/DeliveryServiceShare
+type DeliveryServiceShare struct {
+	DeliveryService string  `json:"delivery_service"`
+	Orders          int64   `json:"orders"`
+	Share           float64 `json:"share"`
+}
+
+// GetDeliveryService returns the value of DeliveryService.
+func (s *DeliveryServiceShare) GetDeliveryService() string {
+	return s.DeliveryService
+}
+
+// GetOrders returns the value of Orders.
+func (s *DeliveryServiceShare) GetOrders() int64 {
+	return s.Orders
+}
+
+// GetShare returns the value of Share.
+func (s *DeliveryServiceShare) GetShare() float64 {
+	return s.Share
+}
+
+// SetDeliveryService sets the value of DeliveryService.
+func (s *DeliveryServiceShare) SetDeliveryService(val string) {
+	s.DeliveryService = val
+}
+
+// SetOrders sets the value of Orders.
+func (s *DeliveryServiceShare) SetOrders(val int64) {
+	s.Orders = val
+}
+
+// SetShare sets the value of Share.
+func (s *DeliveryServiceShare) SetShare(val float64) {
+	s.Share = val
+}
+
+// Ref: #/components/schemas/DeliveryServiceShareResponse
+type DeliveryServiceShareResponse struct {
+	Success  bool                   `json:"success"`
+	Services []DeliveryServiceShare `json:"services"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *DeliveryServiceShareResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetServices returns the value of Services.
+func (s *DeliveryServiceShareResponse) GetServices() []DeliveryServiceShare {
+	return s.Services
+}
+
+// SetSuccess sets the value of Success.
+func (s *DeliveryServiceShareResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetServices sets the value of Services.
+func (s *DeliveryServiceShareResponse) SetServices(val []DeliveryServiceShare) {
+	s.Services = val
+}
+
+// Ref: #/components/schemas/DiscountStatsResponse
+type DiscountStatsResponse struct {
+	Success     bool    `json:"success"`
+	Items       int64   `json:"items"`
+	AverageSale float64 `json:"average_sale"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *DiscountStatsResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetItems returns the value of Items.
+func (s *DiscountStatsResponse) GetItems() int64 {
+	return s.Items
+}
+
+// GetAverageSale returns the value of AverageSale.
+func (s *DiscountStatsResponse) GetAverageSale() float64 {
+	return s.AverageSale
+}
+
+// SetSuccess sets the value of Success.
+func (s *DiscountStatsResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetItems sets the value of Items.
+func (s *DiscountStatsResponse) SetItems(val int64) {
+	s.Items = val
+}
+
+// SetAverageSale sets the value of AverageSale.
+func (s *DiscountStatsResponse) SetAverageSale(val float64) {
+	s.AverageSale = val
+}
+
+// Ref: #/components/schemas/EraseCustomerRequest
+type EraseCustomerRequest struct {
+	CustomerID string `json:"customer_id"`
+}
+
+// GetCustomerID returns the value of CustomerID.
+func (s *EraseCustomerRequest) GetCustomerID() string {
+	return s.CustomerID
+}
+
+// SetCustomerID sets the value of CustomerID.
+func (s *EraseCustomerRequest) SetCustomerID(val string) {
+	s.CustomerID = val
+}
+
+// Ref: #/components/schemas/EraseCustomerResponse
+type EraseCustomerResponse struct {
+	Success              bool     `json:"success"`
+	CustomerID           string   `json:"customer_id"`
+	Tenant               string   `json:"tenant"`
+	OrderUids            []string `json:"order_uids"`
+	OrdersAffected       int      `json:"orders_affected"`
+	DeliveriesAnonymised int      `json:"deliveries_anonymised"`
+	// Снимки архивных заказов, в которых затерта доставка.
+	ArchivedAnonymised int `json:"archived_anonymised"`
+	// События outbox, в которых затерта доставка.
+	EventsAnonymised int       `json:"events_anonymised"`
+	ErasedAt         time.Time `json:"erased_at"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *EraseCustomerResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetCustomerID returns the value of CustomerID.
+func (s *EraseCustomerResponse) GetCustomerID() string {
+	return s.CustomerID
+}
+
+// GetTenant returns the value of Tenant.
+func (s *EraseCustomerResponse) GetTenant() string {
+	return s.Tenant
+}
+
+// GetOrderUids returns the value of OrderUids.
+func (s *EraseCustomerResponse) GetOrderUids() []string {
+	return s.OrderUids
+}
+
+// GetOrdersAffected returns the value of OrdersAffected.
+func (s *EraseCustomerResponse) GetOrdersAffected() int {
+	return s.OrdersAffected
+}
+
+// GetDeliveriesAnonymised returns the value of DeliveriesAnonymised.
+func (s *EraseCustomerResponse) GetDeliveriesAnonymised() int {
+	return s.DeliveriesAnonymised
+}
+
+// GetArchivedAnonymised returns the value of ArchivedAnonymised.
+func (s *EraseCustomerResponse) GetArchivedAnonymised() int {
+	return s.ArchivedAnonymised
+}
+
+// GetEventsAnonymised returns the value of EventsAnonymised.
+func (s *EraseCustomerResponse) GetEventsAnonymised() int {
+	return s.EventsAnonymised
+}
+
+// GetErasedAt returns the value of ErasedAt.
+func (s *EraseCustomerResponse) GetErasedAt() time.Time {
+	return s.ErasedAt
+}
+
+// SetSuccess sets the value of Success.
+func (s *EraseCustomerResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetCustomerID sets the value of CustomerID.
+func (s *EraseCustomerResponse) SetCustomerID(val string) {
+	s.CustomerID = val
+}
+
+// SetTenant sets the value of Tenant.
+func (s *EraseCustomerResponse) SetTenant(val string) {
+	s.Tenant = val
+}
+
+// SetOrderUids sets the value of OrderUids.
+func (s *EraseCustomerResponse) SetOrderUids(val []string) {
+	s.OrderUids = val
+}
+
+// SetOrdersAffected sets the value of OrdersAffected.
+func (s *EraseCustomerResponse) SetOrdersAffected(val int) {
+	s.OrdersAffected = val
+}
+
+// SetDeliveriesAnonymised sets the value of DeliveriesAnonymised.
+func (s *EraseCustomerResponse) SetDeliveriesAnonymised(val int) {
+	s.DeliveriesAnonymised = val
+}
+
+// SetArchivedAnonymised sets the value of ArchivedAnonymised.
+func (s *EraseCustomerResponse) SetArchivedAnonymised(val int) {
+	s.ArchivedAnonymised = val
+}
+
+// SetEventsAnonymised sets the value of EventsAnonymised.
+func (s *EraseCustomerResponse) SetEventsAnonymised(val int) {
+	s.EventsAnonymised = val
+}
+
+// SetErasedAt sets the value of ErasedAt.
+func (s *EraseCustomerResponse) SetErasedAt(val time.Time) {
+	s.ErasedAt = val
+}
+
+// Ref: #/components/schemas/GenerateOrdersRequest
+type GenerateOrdersRequest struct {
+	// Random, replay или template; по умолчанию - GENERATOR_STRATEGY.
+	Strategy OptGenerateOrdersRequestStrategy `json:"strategy"`
+	Count    OptInt                           `json:"count"`
+	// Заказов в секунду, без ограничения если не задано.
+	Rate OptFloat64 `json:"rate"`
+}
+
+// GetStrategy returns the value of Strategy.
+func (s *GenerateOrdersRequest) GetStrategy() OptGenerateOrdersRequestStrategy {
+	return s.Strategy
+}
+
+// GetCount returns the value of Count.
+func (s *GenerateOrdersRequest) GetCount() OptInt {
+	return s.Count
+}
+
+// GetRate returns the value of Rate.
+func (s *GenerateOrdersRequest) GetRate() OptFloat64 {
+	return s.Rate
+}
+
+// SetStrategy sets the value of Strategy.
+func (s *GenerateOrdersRequest) SetStrategy(val OptGenerateOrdersRequestStrategy) {
+	s.Strategy = val
+}
+
+// SetCount sets the value of Count.
+func (s *GenerateOrdersRequest) SetCount(val OptInt) {
+	s.Count = val
+}
+
+// SetRate sets the value of Rate.
+func (s *GenerateOrdersRequest) SetRate(val OptFloat64) {
+	s.Rate = val
+}
+
+// Random, replay или template; по умолчанию - GENERATOR_STRATEGY.
+type GenerateOrdersRequestStrategy string
+
+const (
+	GenerateOrdersRequestStrategyRandom   GenerateOrdersRequestStrategy = "random"
+	GenerateOrdersRequestStrategyReplay   GenerateOrdersRequestStrategy = "replay"
+	GenerateOrdersRequestStrategyTemplate GenerateOrdersRequestStrategy = "template"
 )
 
-// Ref: #/components/schemas/Delivery
-type Delivery struct {
-	Name    string `json:"name"`
-	Phone   string `json:"phone"`
-	Zip     string `json:"zip"`
-	City    string `json:"city"`
-	Address string `json:"address"`
-	Region  string `json:"region"`
-	Email   string `json:"email"`
+// AllValues returns all GenerateOrdersRequestStrategy values.
+func (GenerateOrdersRequestStrategy) AllValues() []GenerateOrdersRequestStrategy {
+	return []GenerateOrdersRequestStrategy{
+		GenerateOrdersRequestStrategyRandom,
+		GenerateOrdersRequestStrategyReplay,
+		GenerateOrdersRequestStrategyTemplate,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GenerateOrdersRequestStrategy) MarshalText() ([]byte, error) {
+	switch s {
+	case GenerateOrdersRequestStrategyRandom:
+		return []byte(s), nil
+	case GenerateOrdersRequestStrategyReplay:
+		return []byte(s), nil
+	case GenerateOrdersRequestStrategyTemplate:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GenerateOrdersRequestStrategy) UnmarshalText(data []byte) error {
+	switch GenerateOrdersRequestStrategy(data) {
+	case GenerateOrdersRequestStrategyRandom:
+		*s = GenerateOrdersRequestStrategyRandom
+		return nil
+	case GenerateOrdersRequestStrategyReplay:
+		*s = GenerateOrdersRequestStrategyReplay
+		return nil
+	case GenerateOrdersRequestStrategyTemplate:
+		*s = GenerateOrdersRequestStrategyTemplate
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/GenerateOrdersResponse
+type GenerateOrdersResponse struct {
+	Success   bool `json:"success"`
+	Generated int  `json:"generated"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *GenerateOrdersResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetGenerated returns the value of Generated.
+func (s *GenerateOrdersResponse) GetGenerated() int {
+	return s.Generated
+}
+
+// SetSuccess sets the value of Success.
+func (s *GenerateOrdersResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetGenerated sets the value of Generated.
+func (s *GenerateOrdersResponse) SetGenerated(val int) {
+	s.Generated = val
+}
+
+// Ref: #/components/schemas/GetOrderRequest
+type GetOrderRequest struct {
+	OrderUID string `json:"order_uid"`
+	// Валюта ISO-4217, в которую пересчитываются суммы ответа.
+	Currency OptString `json:"currency"`
+}
+
+// GetOrderUID returns the value of OrderUID.
+func (s *GetOrderRequest) GetOrderUID() string {
+	return s.OrderUID
+}
+
+// GetCurrency returns the value of Currency.
+func (s *GetOrderRequest) GetCurrency() OptString {
+	return s.Currency
+}
+
+// SetOrderUID sets the value of OrderUID.
+func (s *GetOrderRequest) SetOrderUID(val string) {
+	s.OrderUID = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *GetOrderRequest) SetCurrency(val OptString) {
+	s.Currency = val
+}
+
+// Ref: #/components/schemas/GetOrderResponse
+type GetOrderResponse struct {
+	Success    bool          `json:"success"`
+	Data       Order         `json:"data"`
+	Timestamp  time.Time     `json:"timestamp"`
+	Conversion OptConversion `json:"conversion"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *GetOrderResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetData returns the value of Data.
+func (s *GetOrderResponse) GetData() Order {
+	return s.Data
+}
+
+// GetTimestamp returns the value of Timestamp.
+func (s *GetOrderResponse) GetTimestamp() time.Time {
+	return s.Timestamp
+}
+
+// GetConversion returns the value of Conversion.
+func (s *GetOrderResponse) GetConversion() OptConversion {
+	return s.Conversion
+}
+
+// SetSuccess sets the value of Success.
+func (s *GetOrderResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetData sets the value of Data.
+func (s *GetOrderResponse) SetData(val Order) {
+	s.Data = val
+}
+
+// SetTimestamp sets the value of Timestamp.
+func (s *GetOrderResponse) SetTimestamp(val time.Time) {
+	s.Timestamp = val
+}
+
+// SetConversion sets the value of Conversion.
+func (s *GetOrderResponse) SetConversion(val OptConversion) {
+	s.Conversion = val
+}
+
+// Ref: #/components/schemas/Item
+type Item struct {
+	ChrtID      int    `json:"chrt_id"`
+	TrackNumber string `json:"track_number"`
+	Price       int    `json:"price"`
+	Rid         string `json:"rid"`
+	Name        string `json:"name"`
+	Sale        int    `json:"sale"`
+	Size        string `json:"size"`
+	TotalPrice  int    `json:"total_price"`
+	NmID        int    `json:"nm_id"`
+	Brand       string `json:"brand"`
+	Status      int    `json:"status"`
+}
+
+// GetChrtID returns the value of ChrtID.
+func (s *Item) GetChrtID() int {
+	return s.ChrtID
+}
+
+// GetTrackNumber returns the value of TrackNumber.
+func (s *Item) GetTrackNumber() string {
+	return s.TrackNumber
+}
+
+// GetPrice returns the value of Price.
+func (s *Item) GetPrice() int {
+	return s.Price
+}
+
+// GetRid returns the value of Rid.
+func (s *Item) GetRid() string {
+	return s.Rid
+}
+
+// GetName returns the value of Name.
+func (s *Item) GetName() string {
+	return s.Name
+}
+
+// GetSale returns the value of Sale.
+func (s *Item) GetSale() int {
+	return s.Sale
+}
+
+// GetSize returns the value of Size.
+func (s *Item) GetSize() string {
+	return s.Size
+}
+
+// GetTotalPrice returns the value of TotalPrice.
+func (s *Item) GetTotalPrice() int {
+	return s.TotalPrice
+}
+
+// GetNmID returns the value of NmID.
+func (s *Item) GetNmID() int {
+	return s.NmID
+}
+
+// GetBrand returns the value of Brand.
+func (s *Item) GetBrand() string {
+	return s.Brand
+}
+
+// GetStatus returns the value of Status.
+func (s *Item) GetStatus() int {
+	return s.Status
+}
+
+// SetChrtID sets the value of ChrtID.
+func (s *Item) SetChrtID(val int) {
+	s.ChrtID = val
+}
+
+// SetTrackNumber sets the value of TrackNumber.
+func (s *Item) SetTrackNumber(val string) {
+	s.TrackNumber = val
 }
 
-// GetName returns the value of Name.
-func (s *Delivery) GetName() string {
-	return s.Name
+// SetPrice sets the value of Price.
+func (s *Item) SetPrice(val int) {
+	s.Price = val
 }
 
-// GetPhone returns the value of Phone.
-func (s *Delivery) GetPhone() string {
-	return s.Phone
+// SetRid sets the value of Rid.
+func (s *Item) SetRid(val string) {
+	s.Rid = val
 }
 
-// GetZip returns the value of Zip.
-func (s *Delivery) GetZip() string {
-	return s.Zip
+// SetName sets the value of Name.
+func (s *Item) SetName(val string) {
+	s.Name = val
 }
 
-// GetCity returns the value of City.
-func (s *Delivery) GetCity() string {
-	return s.City
+// SetSale sets the value of Sale.
+func (s *Item) SetSale(val int) {
+	s.Sale = val
 }
 
-// GetAddress returns the value of Address.
-func (s *Delivery) GetAddress() string {
-	return s.Address
+// SetSize sets the value of Size.
+func (s *Item) SetSize(val string) {
+	s.Size = val
 }
 
-// GetRegion returns the value of Region.
-func (s *Delivery) GetRegion() string {
-	return s.Region
+// SetTotalPrice sets the value of TotalPrice.
+func (s *Item) SetTotalPrice(val int) {
+	s.TotalPrice = val
 }
 
-// GetEmail returns the value of Email.
-func (s *Delivery) GetEmail() string {
-	return s.Email
+// SetNmID sets the value of NmID.
+func (s *Item) SetNmID(val int) {
+	s.NmID = val
 }
 
-// SetName sets the value of Name.
-func (s *Delivery) SetName(val string) {
-	s.Name = val
+// SetBrand sets the value of Brand.
+func (s *Item) SetBrand(val string) {
+	s.Brand = val
 }
 
-// SetPhone sets the value of Phone.
-func (s *Delivery) SetPhone(val string) {
-	s.Phone = val
+// SetStatus sets the value of Status.
+func (s *Item) SetStatus(val int) {
+	s.Status = val
 }
 
-// SetZip sets the value of Zip.
-func (s *Delivery) SetZip(val string) {
-	s.Zip = val
+// Ref: #/components/schemas/ItemSales
+type ItemSales struct {
+	Currency string `json:"currency"`
+	NmID     int64  `json:"nm_id"`
+	Name     string `json:"name"`
+	Brand    string `json:"brand"`
+	Items    int64  `json:"items"`
+	Revenue  int64  `json:"revenue"`
 }
 
-// SetCity sets the value of City.
-func (s *Delivery) SetCity(val string) {
-	s.City = val
+// GetCurrency returns the value of Currency.
+func (s *ItemSales) GetCurrency() string {
+	return s.Currency
 }
 
-// SetAddress sets the value of Address.
-func (s *Delivery) SetAddress(val string) {
-	s.Address = val
+// GetNmID returns the value of NmID.
+func (s *ItemSales) GetNmID() int64 {
+	return s.NmID
 }
 
-// SetRegion sets the value of Region.
-func (s *Delivery) SetRegion(val string) {
-	s.Region = val
+// GetName returns the value of Name.
+func (s *ItemSales) GetName() string {
+	return s.Name
 }
 
-// SetEmail sets the value of Email.
-func (s *Delivery) SetEmail(val string) {
-	s.Email = val
+// GetBrand returns the value of Brand.
+func (s *ItemSales) GetBrand() string {
+	return s.Brand
 }
 
-// Ref: #/components/schemas/GetOrderRequest
-type GetOrderRequest struct {
+// GetItems returns the value of Items.
+func (s *ItemSales) GetItems() int64 {
+	return s.Items
+}
+
+// GetRevenue returns the value of Revenue.
+func (s *ItemSales) GetRevenue() int64 {
+	return s.Revenue
+}
+
+// SetCurrency sets the value of Currency.
+func (s *ItemSales) SetCurrency(val string) {
+	s.Currency = val
+}
+
+// SetNmID sets the value of NmID.
+func (s *ItemSales) SetNmID(val int64) {
+	s.NmID = val
+}
+
+// SetName sets the value of Name.
+func (s *ItemSales) SetName(val string) {
+	s.Name = val
+}
+
+// SetBrand sets the value of Brand.
+func (s *ItemSales) SetBrand(val string) {
+	s.Brand = val
+}
+
+// SetItems sets the value of Items.
+func (s *ItemSales) SetItems(val int64) {
+	s.Items = val
+}
+
+// SetRevenue sets the value of Revenue.
+func (s *ItemSales) SetRevenue(val int64) {
+	s.Revenue = val
+}
+
+// Ref: #/components/schemas/Mismatch
+type Mismatch struct {
 	OrderUID string `json:"order_uid"`
+	Tenant   string `json:"tenant"`
+	Rule     string `json:"rule"`
+	Expected int64  `json:"expected"`
+	Actual   int64  `json:"actual"`
+	// Подробности нарушения, для unknown_currency - код валюты.
+	Detail     OptString `json:"detail"`
+	Source     string    `json:"source"`
+	DetectedAt time.Time `json:"detected_at"`
 }
 
 // GetOrderUID returns the value of OrderUID.
-func (s *GetOrderRequest) GetOrderUID() string {
+func (s *Mismatch) GetOrderUID() string {
 	return s.OrderUID
 }
 
+// GetTenant returns the value of Tenant.
+func (s *Mismatch) GetTenant() string {
+	return s.Tenant
+}
+
+// GetRule returns the value of Rule.
+func (s *Mismatch) GetRule() string {
+	return s.Rule
+}
+
+// GetExpected returns the value of Expected.
+func (s *Mismatch) GetExpected() int64 {
+	return s.Expected
+}
+
+// GetActual returns the value of Actual.
+func (s *Mismatch) GetActual() int64 {
+	return s.Actual
+}
+
+// GetDetail returns the value of Detail.
+func (s *Mismatch) GetDetail() OptString {
+	return s.Detail
+}
+
+// GetSource returns the value of Source.
+func (s *Mismatch) GetSource() string {
+	return s.Source
+}
+
+// GetDetectedAt returns the value of DetectedAt.
+func (s *Mismatch) GetDetectedAt() time.Time {
+	return s.DetectedAt
+}
+
 // SetOrderUID sets the value of OrderUID.
-func (s *GetOrderRequest) SetOrderUID(val string) {
+func (s *Mismatch) SetOrderUID(val string) {
 	s.OrderUID = val
 }
 
-// Ref: #/components/schemas/GetOrderResponse
-type GetOrderResponse struct {
-	Success   bool      `json:"success"`
-	Data      Order     `json:"data"`
-	Timestamp time.Time `json:"timestamp"`
+// SetTenant sets the value of Tenant.
+func (s *Mismatch) SetTenant(val string) {
+	s.Tenant = val
 }
 
-// GetSuccess returns the value of Success.
-func (s *GetOrderResponse) GetSuccess() bool {
-	return s.Success
+// SetRule sets the value of Rule.
+func (s *Mismatch) SetRule(val string) {
+	s.Rule = val
 }
 
-// GetData returns the value of Data.
-func (s *GetOrderResponse) GetData() Order {
-	return s.Data
+// SetExpected sets the value of Expected.
+func (s *Mismatch) SetExpected(val int64) {
+	s.Expected = val
 }
 
-// GetTimestamp returns the value of Timestamp.
-func (s *GetOrderResponse) GetTimestamp() time.Time {
-	return s.Timestamp
+// SetActual sets the value of Actual.
+func (s *Mismatch) SetActual(val int64) {
+	s.Actual = val
 }
 
-// SetSuccess sets the value of Success.
-func (s *GetOrderResponse) SetSuccess(val bool) {
-	s.Success = val
+// SetDetail sets the value of Detail.
+func (s *Mismatch) SetDetail(val OptString) {
+	s.Detail = val
 }
 
-// SetData sets the value of Data.
-func (s *GetOrderResponse) SetData(val Order) {
-	s.Data = val
+// SetSource sets the value of Source.
+func (s *Mismatch) SetSource(val string) {
+	s.Source = val
+}
+
+// SetDetectedAt sets the value of DetectedAt.
+func (s *Mismatch) SetDetectedAt(val time.Time) {
+	s.DetectedAt = val
+}
+
+// Ref: #/components/schemas/MismatchRuleCount
+type MismatchRuleCount struct {
+	Rule   string `json:"rule"`
+	Orders int64  `json:"orders"`
+}
+
+// GetRule returns the value of Rule.
+func (s *MismatchRuleCount) GetRule() string {
+	return s.Rule
+}
+
+// GetOrders returns the value of Orders.
+func (s *MismatchRuleCount) GetOrders() int64 {
+	return s.Orders
+}
+
+// SetRule sets the value of Rule.
+func (s *MismatchRuleCount) SetRule(val string) {
+	s.Rule = val
+}
+
+// SetOrders sets the value of Orders.
+func (s *MismatchRuleCount) SetOrders(val int64) {
+	s.Orders = val
+}
+
+// NewOptApplyRetentionRequestMode returns new OptApplyRetentionRequestMode with value set to v.
+func NewOptApplyRetentionRequestMode(v ApplyRetentionRequestMode) OptApplyRetentionRequestMode {
+	return OptApplyRetentionRequestMode{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptApplyRetentionRequestMode is optional ApplyRetentionRequestMode.
+type OptApplyRetentionRequestMode struct {
+	Value ApplyRetentionRequestMode
+	Set   bool
+}
+
+// IsSet returns true if OptApplyRetentionRequestMode was set.
+func (o OptApplyRetentionRequestMode) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptApplyRetentionRequestMode) Reset() {
+	var v ApplyRetentionRequestMode
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptApplyRetentionRequestMode) SetTo(v ApplyRetentionRequestMode) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptApplyRetentionRequestMode) Get() (v ApplyRetentionRequestMode, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptApplyRetentionRequestMode) Or(d ApplyRetentionRequestMode) ApplyRetentionRequestMode {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptConsumerPartitionStatusErrors returns new OptConsumerPartitionStatusErrors with value set to v.
+func NewOptConsumerPartitionStatusErrors(v ConsumerPartitionStatusErrors) OptConsumerPartitionStatusErrors {
+	return OptConsumerPartitionStatusErrors{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptConsumerPartitionStatusErrors is optional ConsumerPartitionStatusErrors.
+type OptConsumerPartitionStatusErrors struct {
+	Value ConsumerPartitionStatusErrors
+	Set   bool
+}
+
+// IsSet returns true if OptConsumerPartitionStatusErrors was set.
+func (o OptConsumerPartitionStatusErrors) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptConsumerPartitionStatusErrors) Reset() {
+	var v ConsumerPartitionStatusErrors
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptConsumerPartitionStatusErrors) SetTo(v ConsumerPartitionStatusErrors) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptConsumerPartitionStatusErrors) Get() (v ConsumerPartitionStatusErrors, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptConsumerPartitionStatusErrors) Or(d ConsumerPartitionStatusErrors) ConsumerPartitionStatusErrors {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptConsumerReplayRequestMode returns new OptConsumerReplayRequestMode with value set to v.
+func NewOptConsumerReplayRequestMode(v ConsumerReplayRequestMode) OptConsumerReplayRequestMode {
+	return OptConsumerReplayRequestMode{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptConsumerReplayRequestMode is optional ConsumerReplayRequestMode.
+type OptConsumerReplayRequestMode struct {
+	Value ConsumerReplayRequestMode
+	Set   bool
+}
+
+// IsSet returns true if OptConsumerReplayRequestMode was set.
+func (o OptConsumerReplayRequestMode) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptConsumerReplayRequestMode) Reset() {
+	var v ConsumerReplayRequestMode
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptConsumerReplayRequestMode) SetTo(v ConsumerReplayRequestMode) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptConsumerReplayRequestMode) Get() (v ConsumerReplayRequestMode, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptConsumerReplayRequestMode) Or(d ConsumerReplayRequestMode) ConsumerReplayRequestMode {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptConversion returns new OptConversion with value set to v.
+func NewOptConversion(v Conversion) OptConversion {
+	return OptConversion{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptConversion is optional Conversion.
+type OptConversion struct {
+	Value Conversion
+	Set   bool
+}
+
+// IsSet returns true if OptConversion was set.
+func (o OptConversion) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptConversion) Reset() {
+	var v Conversion
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptConversion) SetTo(v Conversion) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptConversion) Get() (v Conversion, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptConversion) Or(d Conversion) Conversion {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v time.Time) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional time.Time.
+type OptDate struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetTimestamp sets the value of Timestamp.
-func (s *GetOrderResponse) SetTimestamp(val time.Time) {
-	s.Timestamp = val
+// NewOptGenerateOrdersRequestStrategy returns new OptGenerateOrdersRequestStrategy with value set to v.
+func NewOptGenerateOrdersRequestStrategy(v GenerateOrdersRequestStrategy) OptGenerateOrdersRequestStrategy {
+	return OptGenerateOrdersRequestStrategy{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// Ref: #/components/schemas/Item
-type Item struct {
-	ChrtID      int    `json:"chrt_id"`
-	TrackNumber string `json:"track_number"`
-	Price       int    `json:"price"`
-	Rid         string `json:"rid"`
-	Name        string `json:"name"`
-	Sale        int    `json:"sale"`
-	Size        string `json:"size"`
-	TotalPrice  int    `json:"total_price"`
-	NmID        int    `json:"nm_id"`
-	Brand       string `json:"brand"`
-	Status      int    `json:"status"`
+// OptGenerateOrdersRequestStrategy is optional GenerateOrdersRequestStrategy.
+type OptGenerateOrdersRequestStrategy struct {
+	Value GenerateOrdersRequestStrategy
+	Set   bool
 }
 
-// GetChrtID returns the value of ChrtID.
-func (s *Item) GetChrtID() int {
-	return s.ChrtID
+// IsSet returns true if OptGenerateOrdersRequestStrategy was set.
+func (o OptGenerateOrdersRequestStrategy) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptGenerateOrdersRequestStrategy) Reset() {
+	var v GenerateOrdersRequestStrategy
+	o.Value = v
+	o.Set = false
 }
 
-// GetTrackNumber returns the value of TrackNumber.
-func (s *Item) GetTrackNumber() string {
-	return s.TrackNumber
+// SetTo sets value to v.
+func (o *OptGenerateOrdersRequestStrategy) SetTo(v GenerateOrdersRequestStrategy) {
+	o.Set = true
+	o.Value = v
 }
 
-// GetPrice returns the value of Price.
-func (s *Item) GetPrice() int {
-	return s.Price
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGenerateOrdersRequestStrategy) Get() (v GenerateOrdersRequestStrategy, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetRid returns the value of Rid.
-func (s *Item) GetRid() string {
-	return s.Rid
+// Or returns value if set, or given parameter if does not.
+func (o OptGenerateOrdersRequestStrategy) Or(d GenerateOrdersRequestStrategy) GenerateOrdersRequestStrategy {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// GetName returns the value of Name.
-func (s *Item) GetName() string {
-	return s.Name
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetSale returns the value of Sale.
-func (s *Item) GetSale() int {
-	return s.Sale
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
 }
 
-// GetSize returns the value of Size.
-func (s *Item) GetSize() string {
-	return s.Size
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
 }
 
-// GetTotalPrice returns the value of TotalPrice.
-func (s *Item) GetTotalPrice() int {
-	return s.TotalPrice
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
 }
 
-// GetNmID returns the value of NmID.
-func (s *Item) GetNmID() int {
-	return s.NmID
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetBrand returns the value of Brand.
-func (s *Item) GetBrand() string {
-	return s.Brand
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// GetStatus returns the value of Status.
-func (s *Item) GetStatus() int {
-	return s.Status
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// SetChrtID sets the value of ChrtID.
-func (s *Item) SetChrtID(val int) {
-	s.ChrtID = val
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
 }
 
-// SetTrackNumber sets the value of TrackNumber.
-func (s *Item) SetTrackNumber(val string) {
-	s.TrackNumber = val
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
 }
 
-// SetPrice sets the value of Price.
-func (s *Item) SetPrice(val int) {
-	s.Price = val
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetRid sets the value of Rid.
-func (s *Item) SetRid(val string) {
-	s.Rid = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetName sets the value of Name.
-func (s *Item) SetName(val string) {
-	s.Name = val
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetSale sets the value of Sale.
-func (s *Item) SetSale(val int) {
-	s.Sale = val
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// SetSize sets the value of Size.
-func (s *Item) SetSize(val string) {
-	s.Size = val
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
 }
 
-// SetTotalPrice sets the value of TotalPrice.
-func (s *Item) SetTotalPrice(val int) {
-	s.TotalPrice = val
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
 }
 
-// SetNmID sets the value of NmID.
-func (s *Item) SetNmID(val int) {
-	s.NmID = val
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetBrand sets the value of Brand.
-func (s *Item) SetBrand(val string) {
-	s.Brand = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetStatus sets the value of Status.
-func (s *Item) SetStatus(val int) {
-	s.Status = val
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
 // Ref: #/components/schemas/Order
 type Order struct {
-	OrderUID          string    `json:"order_uid"`
+	OrderUID string `json:"order_uid"`
+	Tenant   string `json:"tenant"`
+	// Исходный order_uid, если он не UUID; order_uid тогда получен из
+	// него как UUID v5.
+	SourceOrderUID    OptString `json:"source_order_uid"`
 	TrackNumber       string    `json:"track_number"`
 	Entry             string    `json:"entry"`
 	Locale            string    `json:"locale"`
@@ -287,6 +2778,16 @@ func (s *Order) GetOrderUID() string {
 	return s.OrderUID
 }
 
+// GetTenant returns the value of Tenant.
+func (s *Order) GetTenant() string {
+	return s.Tenant
+}
+
+// GetSourceOrderUID returns the value of SourceOrderUID.
+func (s *Order) GetSourceOrderUID() OptString {
+	return s.SourceOrderUID
+}
+
 // GetTrackNumber returns the value of TrackNumber.
 func (s *Order) GetTrackNumber() string {
 	return s.TrackNumber
@@ -357,6 +2858,16 @@ func (s *Order) SetOrderUID(val string) {
 	s.OrderUID = val
 }
 
+// SetTenant sets the value of Tenant.
+func (s *Order) SetTenant(val string) {
+	s.Tenant = val
+}
+
+// SetSourceOrderUID sets the value of SourceOrderUID.
+func (s *Order) SetSourceOrderUID(val OptString) {
+	s.SourceOrderUID = val
+}
+
 // SetTrackNumber sets the value of TrackNumber.
 func (s *Order) SetTrackNumber(val string) {
 	s.TrackNumber = val
@@ -535,3 +3046,299 @@ func (s *Payment) SetGoodsTotal(val int) {
 func (s *Payment) SetCustomFee(val int) {
 	s.CustomFee = val
 }
+
+// Ref: #/components/schemas/ReconciliationReportResponse
+type ReconciliationReportResponse struct {
+	Success    bool                `json:"success"`
+	Total      int64               `json:"total"`
+	ByRule     []MismatchRuleCount `json:"by_rule"`
+	Mismatches []Mismatch          `json:"mismatches"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *ReconciliationReportResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetTotal returns the value of Total.
+func (s *ReconciliationReportResponse) GetTotal() int64 {
+	return s.Total
+}
+
+// GetByRule returns the value of ByRule.
+func (s *ReconciliationReportResponse) GetByRule() []MismatchRuleCount {
+	return s.ByRule
+}
+
+// GetMismatches returns the value of Mismatches.
+func (s *ReconciliationReportResponse) GetMismatches() []Mismatch {
+	return s.Mismatches
+}
+
+// SetSuccess sets the value of Success.
+func (s *ReconciliationReportResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetTotal sets the value of Total.
+func (s *ReconciliationReportResponse) SetTotal(val int64) {
+	s.Total = val
+}
+
+// SetByRule sets the value of ByRule.
+func (s *ReconciliationReportResponse) SetByRule(val []MismatchRuleCount) {
+	s.ByRule = val
+}
+
+// SetMismatches sets the value of Mismatches.
+func (s *ReconciliationReportResponse) SetMismatches(val []Mismatch) {
+	s.Mismatches = val
+}
+
+// Ref: #/components/schemas/ReconciliationScanResponse
+type ReconciliationScanResponse struct {
+	Success    bool  `json:"success"`
+	Scanned    int64 `json:"scanned"`
+	Mismatches int64 `json:"mismatches"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *ReconciliationScanResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetScanned returns the value of Scanned.
+func (s *ReconciliationScanResponse) GetScanned() int64 {
+	return s.Scanned
+}
+
+// GetMismatches returns the value of Mismatches.
+func (s *ReconciliationScanResponse) GetMismatches() int64 {
+	return s.Mismatches
+}
+
+// SetSuccess sets the value of Success.
+func (s *ReconciliationScanResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetScanned sets the value of Scanned.
+func (s *ReconciliationScanResponse) SetScanned(val int64) {
+	s.Scanned = val
+}
+
+// SetMismatches sets the value of Mismatches.
+func (s *ReconciliationScanResponse) SetMismatches(val int64) {
+	s.Mismatches = val
+}
+
+// Ref: #/components/schemas/RegionOrders
+type RegionOrders struct {
+	Region string `json:"region"`
+	City   string `json:"city"`
+	Orders int64  `json:"orders"`
+}
+
+// GetRegion returns the value of Region.
+func (s *RegionOrders) GetRegion() string {
+	return s.Region
+}
+
+// GetCity returns the value of City.
+func (s *RegionOrders) GetCity() string {
+	return s.City
+}
+
+// GetOrders returns the value of Orders.
+func (s *RegionOrders) GetOrders() int64 {
+	return s.Orders
+}
+
+// SetRegion sets the value of Region.
+func (s *RegionOrders) SetRegion(val string) {
+	s.Region = val
+}
+
+// SetCity sets the value of City.
+func (s *RegionOrders) SetCity(val string) {
+	s.City = val
+}
+
+// SetOrders sets the value of Orders.
+func (s *RegionOrders) SetOrders(val int64) {
+	s.Orders = val
+}
+
+// Ref: #/components/schemas/RegionOrdersResponse
+type RegionOrdersResponse struct {
+	Success bool           `json:"success"`
+	Regions []RegionOrders `json:"regions"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *RegionOrdersResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetRegions returns the value of Regions.
+func (s *RegionOrdersResponse) GetRegions() []RegionOrders {
+	return s.Regions
+}
+
+// SetSuccess sets the value of Success.
+func (s *RegionOrdersResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetRegions sets the value of Regions.
+func (s *RegionOrdersResponse) SetRegions(val []RegionOrders) {
+	s.Regions = val
+}
+
+// Ref: #/components/schemas/RevenuePoint
+type RevenuePoint struct {
+	Day          time.Time `json:"day"`
+	Currency     string    `json:"currency"`
+	Provider     string    `json:"provider"`
+	Orders       int64     `json:"orders"`
+	Revenue      int64     `json:"revenue"`
+	DeliveryCost int64     `json:"delivery_cost"`
+}
+
+// GetDay returns the value of Day.
+func (s *RevenuePoint) GetDay() time.Time {
+	return s.Day
+}
+
+// GetCurrency returns the value of Currency.
+func (s *RevenuePoint) GetCurrency() string {
+	return s.Currency
+}
+
+// GetProvider returns the value of Provider.
+func (s *RevenuePoint) GetProvider() string {
+	return s.Provider
+}
+
+// GetOrders returns the value of Orders.
+func (s *RevenuePoint) GetOrders() int64 {
+	return s.Orders
+}
+
+// GetRevenue returns the value of Revenue.
+func (s *RevenuePoint) GetRevenue() int64 {
+	return s.Revenue
+}
+
+// GetDeliveryCost returns the value of DeliveryCost.
+func (s *RevenuePoint) GetDeliveryCost() int64 {
+	return s.DeliveryCost
+}
+
+// SetDay sets the value of Day.
+func (s *RevenuePoint) SetDay(val time.Time) {
+	s.Day = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *RevenuePoint) SetCurrency(val string) {
+	s.Currency = val
+}
+
+// SetProvider sets the value of Provider.
+func (s *RevenuePoint) SetProvider(val string) {
+	s.Provider = val
+}
+
+// SetOrders sets the value of Orders.
+func (s *RevenuePoint) SetOrders(val int64) {
+	s.Orders = val
+}
+
+// SetRevenue sets the value of Revenue.
+func (s *RevenuePoint) SetRevenue(val int64) {
+	s.Revenue = val
+}
+
+// SetDeliveryCost sets the value of DeliveryCost.
+func (s *RevenuePoint) SetDeliveryCost(val int64) {
+	s.DeliveryCost = val
+}
+
+// Ref: #/components/schemas/RevenueResponse
+type RevenueResponse struct {
+	Success bool           `json:"success"`
+	Points  []RevenuePoint `json:"points"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *RevenueResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetPoints returns the value of Points.
+func (s *RevenueResponse) GetPoints() []RevenuePoint {
+	return s.Points
+}
+
+// SetSuccess sets the value of Success.
+func (s *RevenueResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetPoints sets the value of Points.
+func (s *RevenueResponse) SetPoints(val []RevenuePoint) {
+	s.Points = val
+}
+
+// Ref: #/components/schemas/TopBrandsResponse
+type TopBrandsResponse struct {
+	Success bool         `json:"success"`
+	Brands  []BrandSales `json:"brands"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *TopBrandsResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetBrands returns the value of Brands.
+func (s *TopBrandsResponse) GetBrands() []BrandSales {
+	return s.Brands
+}
+
+// SetSuccess sets the value of Success.
+func (s *TopBrandsResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetBrands sets the value of Brands.
+func (s *TopBrandsResponse) SetBrands(val []BrandSales) {
+	s.Brands = val
+}
+
+// Ref: #/components/schemas/TopItemsResponse
+type TopItemsResponse struct {
+	Success bool        `json:"success"`
+	Items   []ItemSales `json:"items"`
+}
+
+// GetSuccess returns the value of Success.
+func (s *TopItemsResponse) GetSuccess() bool {
+	return s.Success
+}
+
+// GetItems returns the value of Items.
+func (s *TopItemsResponse) GetItems() []ItemSales {
+	return s.Items
+}
+
+// SetSuccess sets the value of Success.
+func (s *TopItemsResponse) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetItems sets the value of Items.
+func (s *TopItemsResponse) SetItems(val []ItemSales) {
+	s.Items = val
+}
@@ -8,12 +8,137 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// ApplyRetention implements ApplyRetention operation.
+	//
+	// Удаление или архивирование старых ордеров.
+	//
+	// POST /admin/retention/apply
+	ApplyRetention(ctx context.Context, req *ApplyRetentionRequest) (*ApplyRetentionResponse, error)
+	// DeleteOrder implements DeleteOrder operation.
+	//
+	// Мягкое удаление ордера.
+	//
+	// POST /order/delete-order
+	DeleteOrder(ctx context.Context, req *DeleteOrderRequest, params DeleteOrderParams) (*DeleteOrderResponse, error)
+	// EraseCustomer implements EraseCustomer operation.
+	//
+	// Обезличивание персональных данных покупателя.
+	//
+	// POST /customer/erase
+	EraseCustomer(ctx context.Context, req *EraseCustomerRequest, params EraseCustomerParams) (*EraseCustomerResponse, error)
+	// GenerateOrders implements GenerateOrders operation.
+	//
+	// Генерация тестовых ордеров в Kafka.
+	//
+	// POST /generate
+	GenerateOrders(ctx context.Context, req *GenerateOrdersRequest) (*GenerateOrdersResponse, error)
+	// GetConsumerStatus implements GetConsumerStatus operation.
+	//
+	// Участники группы, назначенные партиции, оффсеты,
+	// отставание и ошибки обработки.
+	// Доступно на экземплярах с consumer; ошибки запросов к
+	// брокерам попадают в errors, отчет отдается частично.
+	//
+	// GET /admin/consumer
+	GetConsumerStatus(ctx context.Context) (*ConsumerStatus, error)
+	// GetCustomerSummary implements GetCustomerSummary operation.
+	//
+	// Сводка по покупателю.
+	//
+	// GET /customers/{id}/summary
+	GetCustomerSummary(ctx context.Context, params GetCustomerSummaryParams) (*CustomerSummaryResponse, error)
+	// GetDeliveryServiceShare implements GetDeliveryServiceShare operation.
+	//
+	// Доля служб доставки.
+	//
+	// GET /analytics/delivery-services
+	GetDeliveryServiceShare(ctx context.Context, params GetDeliveryServiceShareParams) (*DeliveryServiceShareResponse, error)
+	// GetDiscountStats implements GetDiscountStats operation.
+	//
+	// Средняя скидка по товарам.
+	//
+	// GET /analytics/discount
+	GetDiscountStats(ctx context.Context, params GetDiscountStatsParams) (*DiscountStatsResponse, error)
 	// GetOrder implements GetOrder operation.
 	//
 	// Получение ордера по ID.
 	//
 	// POST /order/get-order
-	GetOrder(ctx context.Context, req *GetOrderRequest) (*GetOrderResponse, error)
+	GetOrder(ctx context.Context, req *GetOrderRequest, params GetOrderParams) (*GetOrderResponse, error)
+	// GetReconciliationReport implements GetReconciliationReport operation.
+	//
+	// Отчет о расхождениях в суммах ордеров.
+	//
+	// GET /reconciliation/report
+	GetReconciliationReport(ctx context.Context, params GetReconciliationReportParams) (*ReconciliationReportResponse, error)
+	// GetRegionOrders implements GetRegionOrders operation.
+	//
+	// Ордера по регионам и городам.
+	//
+	// GET /analytics/regions
+	GetRegionOrders(ctx context.Context, params GetRegionOrdersParams) (*RegionOrdersResponse, error)
+	// GetRevenue implements GetRevenue operation.
+	//
+	// Выручка по дням, валютам и платежным провайдерам.
+	//
+	// GET /analytics/revenue
+	GetRevenue(ctx context.Context, params GetRevenueParams) (*RevenueResponse, error)
+	// GetTopBrands implements GetTopBrands operation.
+	//
+	// Выручка в разных валютах не складывается, limit
+	// действует на каждую валюту.
+	//
+	// GET /analytics/top-brands
+	GetTopBrands(ctx context.Context, params GetTopBrandsParams) (*TopBrandsResponse, error)
+	// GetTopItems implements GetTopItems operation.
+	//
+	// Выручка в разных валютах не складывается, limit
+	// действует на каждую валюту.
+	//
+	// GET /analytics/top-items
+	GetTopItems(ctx context.Context, params GetTopItemsParams) (*TopItemsResponse, error)
+	// ListCustomerOrders implements ListCustomerOrders operation.
+	//
+	// Ордера покупателя.
+	//
+	// GET /customers/{id}/orders
+	ListCustomerOrders(ctx context.Context, params ListCustomerOrdersParams) (*CustomerOrdersResponse, error)
+	// PauseConsumer implements PauseConsumer operation.
+	//
+	// Новые сообщения не берутся, начатое дообрабатывается;
+	//  группа продолжает heartbeat, ребаланса нет.
+	// Доступно на экземплярах с consumer.
+	//
+	// POST /admin/consumer/pause
+	PauseConsumer(ctx context.Context) (*ConsumerFlowStatus, error)
+	// ReplayConsumer implements ReplayConsumer operation.
+	//
+	// Диапазон [from, to) читается отдельными reader'ами без
+	// группы, оффсеты и участники живой группы не меняются.
+	// Доступно на экземплярах с consumer.
+	//
+	// POST /admin/consumer/replay
+	ReplayConsumer(ctx context.Context, req *ConsumerReplayRequest) (ReplayConsumerRes, error)
+	// ResumeConsumer implements ResumeConsumer operation.
+	//
+	// Снимает ручную паузу и сбрасывает выключатель; если
+	// причина срабатывания не ушла, он откроется снова.
+	// Доступно на экземплярах с consumer.
+	//
+	// POST /admin/consumer/resume
+	ResumeConsumer(ctx context.Context) (*ConsumerFlowStatus, error)
+	// RunReconciliationScan implements RunReconciliationScan operation.
+	//
+	// Сверка сумм всех ордеров в БД.
+	//
+	// POST /admin/reconciliation/scan
+	RunReconciliationScan(ctx context.Context) (*ReconciliationScanResponse, error)
+	// SetConsumerRate implements SetConsumerRate operation.
+	//
+	// Доступно на экземплярах с consumer.
+	//
+	// POST /admin/consumer/rate
+	SetConsumerRate(ctx context.Context, req *ConsumerRateRequest) (*ConsumerFlowStatus, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
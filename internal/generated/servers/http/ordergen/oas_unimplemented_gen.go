@@ -13,11 +13,195 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// ApplyRetention implements ApplyRetention operation.
+//
+// Удаление или архивирование старых ордеров.
+//
+// POST /admin/retention/apply
+func (UnimplementedHandler) ApplyRetention(ctx context.Context, req *ApplyRetentionRequest) (r *ApplyRetentionResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteOrder implements DeleteOrder operation.
+//
+// Мягкое удаление ордера.
+//
+// POST /order/delete-order
+func (UnimplementedHandler) DeleteOrder(ctx context.Context, req *DeleteOrderRequest, params DeleteOrderParams) (r *DeleteOrderResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// EraseCustomer implements EraseCustomer operation.
+//
+// Обезличивание персональных данных покупателя.
+//
+// POST /customer/erase
+func (UnimplementedHandler) EraseCustomer(ctx context.Context, req *EraseCustomerRequest, params EraseCustomerParams) (r *EraseCustomerResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GenerateOrders implements GenerateOrders operation.
+//
+// Генерация тестовых ордеров в Kafka.
+//
+// POST /generate
+func (UnimplementedHandler) GenerateOrders(ctx context.Context, req *GenerateOrdersRequest) (r *GenerateOrdersResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetConsumerStatus implements GetConsumerStatus operation.
+//
+// Участники группы, назначенные партиции, оффсеты,
+// отставание и ошибки обработки.
+// Доступно на экземплярах с consumer; ошибки запросов к
+// брокерам попадают в errors, отчет отдается частично.
+//
+// GET /admin/consumer
+func (UnimplementedHandler) GetConsumerStatus(ctx context.Context) (r *ConsumerStatus, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetCustomerSummary implements GetCustomerSummary operation.
+//
+// Сводка по покупателю.
+//
+// GET /customers/{id}/summary
+func (UnimplementedHandler) GetCustomerSummary(ctx context.Context, params GetCustomerSummaryParams) (r *CustomerSummaryResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetDeliveryServiceShare implements GetDeliveryServiceShare operation.
+//
+// Доля служб доставки.
+//
+// GET /analytics/delivery-services
+func (UnimplementedHandler) GetDeliveryServiceShare(ctx context.Context, params GetDeliveryServiceShareParams) (r *DeliveryServiceShareResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetDiscountStats implements GetDiscountStats operation.
+//
+// Средняя скидка по товарам.
+//
+// GET /analytics/discount
+func (UnimplementedHandler) GetDiscountStats(ctx context.Context, params GetDiscountStatsParams) (r *DiscountStatsResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetOrder implements GetOrder operation.
 //
 // Получение ордера по ID.
 //
 // POST /order/get-order
-func (UnimplementedHandler) GetOrder(ctx context.Context, req *GetOrderRequest) (r *GetOrderResponse, _ error) {
+func (UnimplementedHandler) GetOrder(ctx context.Context, req *GetOrderRequest, params GetOrderParams) (r *GetOrderResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetReconciliationReport implements GetReconciliationReport operation.
+//
+// Отчет о расхождениях в суммах ордеров.
+//
+// GET /reconciliation/report
+func (UnimplementedHandler) GetReconciliationReport(ctx context.Context, params GetReconciliationReportParams) (r *ReconciliationReportResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetRegionOrders implements GetRegionOrders operation.
+//
+// Ордера по регионам и городам.
+//
+// GET /analytics/regions
+func (UnimplementedHandler) GetRegionOrders(ctx context.Context, params GetRegionOrdersParams) (r *RegionOrdersResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetRevenue implements GetRevenue operation.
+//
+// Выручка по дням, валютам и платежным провайдерам.
+//
+// GET /analytics/revenue
+func (UnimplementedHandler) GetRevenue(ctx context.Context, params GetRevenueParams) (r *RevenueResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetTopBrands implements GetTopBrands operation.
+//
+// Выручка в разных валютах не складывается, limit
+// действует на каждую валюту.
+//
+// GET /analytics/top-brands
+func (UnimplementedHandler) GetTopBrands(ctx context.Context, params GetTopBrandsParams) (r *TopBrandsResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetTopItems implements GetTopItems operation.
+//
+// Выручка в разных валютах не складывается, limit
+// действует на каждую валюту.
+//
+// GET /analytics/top-items
+func (UnimplementedHandler) GetTopItems(ctx context.Context, params GetTopItemsParams) (r *TopItemsResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListCustomerOrders implements ListCustomerOrders operation.
+//
+// Ордера покупателя.
+//
+// GET /customers/{id}/orders
+func (UnimplementedHandler) ListCustomerOrders(ctx context.Context, params ListCustomerOrdersParams) (r *CustomerOrdersResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PauseConsumer implements PauseConsumer operation.
+//
+// Новые сообщения не берутся, начатое дообрабатывается;
+//
+//	группа продолжает heartbeat, ребаланса нет.
+//
+// Доступно на экземплярах с consumer.
+//
+// POST /admin/consumer/pause
+func (UnimplementedHandler) PauseConsumer(ctx context.Context) (r *ConsumerFlowStatus, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ReplayConsumer implements ReplayConsumer operation.
+//
+// Диапазон [from, to) читается отдельными reader'ами без
+// группы, оффсеты и участники живой группы не меняются.
+// Доступно на экземплярах с consumer.
+//
+// POST /admin/consumer/replay
+func (UnimplementedHandler) ReplayConsumer(ctx context.Context, req *ConsumerReplayRequest) (r ReplayConsumerRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ResumeConsumer implements ResumeConsumer operation.
+//
+// Снимает ручную паузу и сбрасывает выключатель; если
+// причина срабатывания не ушла, он откроется снова.
+// Доступно на экземплярах с consumer.
+//
+// POST /admin/consumer/resume
+func (UnimplementedHandler) ResumeConsumer(ctx context.Context) (r *ConsumerFlowStatus, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RunReconciliationScan implements RunReconciliationScan operation.
+//
+// Сверка сумм всех ордеров в БД.
+//
+// POST /admin/reconciliation/scan
+func (UnimplementedHandler) RunReconciliationScan(ctx context.Context) (r *ReconciliationScanResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetConsumerRate implements SetConsumerRate operation.
+//
+// Доступно на экземплярах с consumer.
+//
+// POST /admin/consumer/rate
+func (UnimplementedHandler) SetConsumerRate(ctx context.Context, req *ConsumerRateRequest) (r *ConsumerFlowStatus, _ error) {
 	return r, ht.ErrNotImplemented
 }
@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"L0WB/internal/domain"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type City struct {
+	Name      string `json:"name"`
+	Region    string `json:"region"`
+	ZipPrefix string `json:"zip_prefix"`
+}
+
+type Product struct {
+	Name     string   `json:"name"`
+	Sizes    []string `json:"sizes"`
+	MinPrice int      `json:"min_price"`
+	MaxPrice int      `json:"max_price"`
+}
+
+// Dictionaries - справочники, из которых собираются заказы.
+// Порядок брендов важен: первые встречаются чаще (распределение Zipf)
+type Dictionaries struct {
+	FirstNames       []string  `json:"first_names"`
+	LastNames        []string  `json:"last_names"`
+	Cities           []City    `json:"cities"`
+	Streets          []string  `json:"streets"`
+	EmailDomains     []string  `json:"email_domains"`
+	Brands           []string  `json:"brands"`
+	Products         []Product `json:"products"`
+	Currencies       []string  `json:"currencies"`
+	Providers        []string  `json:"providers"`
+	Banks            []string  `json:"banks"`
+	DeliveryServices []string  `json:"delivery_services"`
+	Locales          []string  `json:"locales"`
+}
+
+func DefaultDictionaries() Dictionaries {
+	return Dictionaries{
+		FirstNames:   []string{"Ivan", "Anna", "Sergey", "Maria", "Dmitry", "Elena", "Alexey", "Olga", "Nikita", "Daria"},
+		LastNames:    []string{"Ivanov", "Petrova", "Smirnov", "Kuznetsova", "Popov", "Volkova", "Sokolov", "Lebedeva"},
+		Streets:      []string{"Lenina", "Pushkina", "Gagarina", "Mira", "Sadovaya", "Tverskaya", "Nevsky"},
+		EmailDomains: []string{"mail.ru", "gmail.com", "yandex.ru"},
+		Cities: []City{
+			{Name: "Moscow", Region: "Moscow", ZipPrefix: "101"},
+			{Name: "SPb", Region: "Leningrad", ZipPrefix: "190"},
+			{Name: "Kazan", Region: "Tatarstan", ZipPrefix: "420"},
+			{Name: "Novosibirsk", Region: "Siberia", ZipPrefix: "630"},
+			{Name: "Yekaterinburg", Region: "Sverdlovsk", ZipPrefix: "620"},
+		},
+		Brands: []string{"Nike", "Adidas", "Puma", "Reebok", "New Balance", "Asics", "Fila", "Kappa"},
+		Products: []Product{
+			{Name: "T-Shirt", Sizes: []string{"S", "M", "L", "XL"}, MinPrice: 500, MaxPrice: 3000},
+			{Name: "Jeans", Sizes: []string{"28", "30", "32", "34"}, MinPrice: 2000, MaxPrice: 8000},
+			{Name: "Shoes", Sizes: []string{"40", "41", "42", "43", "44"}, MinPrice: 3000, MaxPrice: 15000},
+			{Name: "Jacket", Sizes: []string{"S", "M", "L", "XL"}, MinPrice: 4000, MaxPrice: 20000},
+			{Name: "Hat", Sizes: []string{"0"}, MinPrice: 300, MaxPrice: 2000},
+		},
+		Currencies:       []string{"RUB", "USD", "EUR", "KZT"},
+		Providers:        []string{"wbpay", "paypal", "stripe"},
+		Banks:            []string{"alpha", "sber", "tinkoff"},
+		DeliveryServices: []string{"postal", "courier", "pickup"},
+		Locales:          []string{"en", "ru", "kz"},
+	}
+}
+
+// LoadDictionaries читает справочники из JSON-файла. Отсутствующие в файле справочники берутся по умолчанию
+func LoadDictionaries(path string) (Dictionaries, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Dictionaries{}, fmt.Errorf("error reading dictionaries: %v", err)
+	}
+
+	var loaded Dictionaries
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return Dictionaries{}, fmt.Errorf("error parsing dictionaries %s: %v", path, err)
+	}
+
+	dict := DefaultDictionaries()
+	override(&dict.FirstNames, loaded.FirstNames)
+	override(&dict.LastNames, loaded.LastNames)
+	override(&dict.Cities, loaded.Cities)
+	override(&dict.Streets, loaded.Streets)
+	override(&dict.EmailDomains, loaded.EmailDomains)
+	override(&dict.Brands, loaded.Brands)
+	override(&dict.Products, loaded.Products)
+	override(&dict.Currencies, loaded.Currencies)
+	override(&dict.Providers, loaded.Providers)
+	override(&dict.Banks, loaded.Banks)
+	override(&dict.DeliveryServices, loaded.DeliveryServices)
+	override(&dict.Locales, loaded.Locales)
+
+	if err := dict.validate(); err != nil {
+		return Dictionaries{}, fmt.Errorf("invalid dictionaries %s: %v", path, err)
+	}
+	return dict, nil
+}
+
+func override[T any](dst *[]T, src []T) {
+	if len(src) > 0 {
+		*dst = src
+	}
+}
+
+func (d Dictionaries) validate() error {
+	lists := map[string]int{
+		"first_names": len(d.FirstNames), "last_names": len(d.LastNames), "cities": len(d.Cities),
+		"streets": len(d.Streets), "email_domains": len(d.EmailDomains), "brands": len(d.Brands),
+		"products": len(d.Products), "currencies": len(d.Currencies), "providers": len(d.Providers),
+		"banks": len(d.Banks), "delivery_services": len(d.DeliveryServices), "locales": len(d.Locales),
+	}
+	for name, size := range lists {
+		if size == 0 {
+			return fmt.Errorf("dictionary %s is empty", name)
+		}
+	}
+	for _, code := range d.Currencies {
+		if _, err := domain.ParseCurrency(code); err != nil {
+			return err
+		}
+	}
+	for _, p := range d.Products {
+		if len(p.Sizes) == 0 {
+			return fmt.Errorf("product %q has no sizes", p.Name)
+		}
+		if p.MinPrice <= 0 || p.MaxPrice < p.MinPrice {
+			return fmt.Errorf("product %q has invalid price range %d..%d", p.Name, p.MinPrice, p.MaxPrice)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"L0WB/internal/domain"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Стратегии генерации
+const (
+	StrategyRandom   = "random"
+	StrategyReplay   = "replay"
+	StrategyTemplate = "template"
+)
+
+var ErrUnknownStrategy = errors.New("unknown generator strategy")
+
+type Strategy interface {
+	GenerateFakeOrder() *domain.CompleteFakeOrder
+}
+
+// Generator выбирает стратегию по имени при каждом вызове
+type Generator struct {
+	strategies      map[string]Strategy
+	defaultStrategy string
+}
+
+func NewGenerator(defaultStrategy string, strategies map[string]Strategy) (*Generator, error) {
+	if _, ok := strategies[defaultStrategy]; !ok {
+		return nil, fmt.Errorf("%w: default %q is not configured", ErrUnknownStrategy, defaultStrategy)
+	}
+	return &Generator{
+		strategies:      strategies,
+		defaultStrategy: defaultStrategy,
+	}, nil
+}
+
+// Strategy возвращает стратегию по имени, пустое имя - стратегия по умолчанию
+func (g *Generator) Strategy(name string) (Strategy, error) {
+	if name == "" {
+		name = g.defaultStrategy
+	}
+	strategy, ok := g.strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownStrategy, name, g.Strategies())
+	}
+	return strategy, nil
+}
+
+func (g *Generator) Strategies() []string {
+	names := make([]string, 0, len(g.strategies))
+	for name := range g.strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *Generator) DefaultStrategy() string {
+	return g.defaultStrategy
+}
+
+func (g *Generator) GenerateFakeOrders(strategy string, count int) ([]*domain.CompleteFakeOrder, error) {
+	s, err := g.Strategy(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*domain.CompleteFakeOrder, 0, count)
+	for i := 0; i < count; i++ {
+		orders = append(orders, s.GenerateFakeOrder())
+	}
+	return orders, nil
+}
+
+// Settings - настройки генератора из конфигурации приложения или флагов команды
+type Settings struct {
+	Strategy     string
+	Seed         int64 // 0 - seed от часов
+	Dictionaries string
+	InvalidRate  float64
+	ReplayFile   string
+	TemplateFile string
+}
+
+// FromSettings собирает генератор: random доступен всегда, replay и template - если задан файл
+func FromSettings(s Settings) (*Generator, error) {
+	cfg := DefaultConfig()
+	if s.Seed != 0 {
+		cfg.Seed = s.Seed
+	}
+	if s.Dictionaries != "" {
+		dict, err := LoadDictionaries(s.Dictionaries)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Dictionaries = dict
+	}
+	cfg.InvalidRate = s.InvalidRate
+
+	random, err := NewRandom(cfg)
+	if err != nil {
+		return nil, err
+	}
+	strategies := map[string]Strategy{StrategyRandom: random}
+
+	if s.ReplayFile != "" {
+		replay, err := LoadReplay(s.ReplayFile)
+		if err != nil {
+			return nil, err
+		}
+		strategies[StrategyReplay] = replay
+	}
+
+	if s.TemplateFile != "" {
+		template, err := LoadTemplate(s.TemplateFile, cfg.Seed)
+		if err != nil {
+			return nil, err
+		}
+		strategies[StrategyTemplate] = template
+	}
+
+	strategy := s.Strategy
+	if strategy == "" {
+		strategy = StrategyRandom
+	}
+	return NewGenerator(strategy, strategies)
+}
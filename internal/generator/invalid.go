@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"L0WB/internal/domain"
+	"fmt"
+)
+
+// InvalidKind - вид намеренной порчи заказа для проверки валидации на стороне consumer
+type InvalidKind string
+
+const (
+	InvalidOrderUID InvalidKind = "order_uid" // пустой order_uid; не-UUID id принимается как legacy
+	InvalidCurrency InvalidKind = "currency"  // неизвестная валюта, заказ попадает в отчет сверки
+	InvalidTotals   InvalidKind = "totals"    // сумма оплаты не сходится, заказ попадает в отчет сверки
+	InvalidDate     InvalidKind = "date"      // date_created в неподдерживаемом формате
+	InvalidNoItems  InvalidKind = "no_items"  // заказ без позиций
+)
+
+var AllInvalidKinds = []InvalidKind{InvalidOrderUID, InvalidCurrency, InvalidTotals, InvalidDate, InvalidNoItems}
+
+func ParseInvalidKind(s string) (InvalidKind, error) {
+	for _, kind := range AllInvalidKinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown invalid order kind %q", s)
+}
+
+func (g *Random) corrupt(order *domain.CompleteFakeOrder, kind InvalidKind) {
+	switch kind {
+	case InvalidOrderUID:
+		order.OrderUID = ""
+	case InvalidCurrency:
+		order.Payment.Currency = "ZZZ"
+	case InvalidTotals:
+		order.Payment.Amount += g.rnd.Intn(1000) + 1
+	case InvalidDate:
+		order.DateCreated = "31/12/2024 25:61"
+	case InvalidNoItems:
+		order.Items = nil
+	}
+}
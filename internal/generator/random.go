@@ -0,0 +1,237 @@
+package generator
+
+import (
+	"L0WB/internal/domain"
+	"fmt"
+	"github.com/google/uuid"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	Seed         int64
+	Dictionaries Dictionaries
+
+	// Параметр s распределения Zipf (> 1): чем больше, тем сильнее перекос к первым брендам и постоянным покупателям
+	ZipfS float64
+	// Максимальное число покупателей; когда пул заполнен, заказы делают только уже известные покупатели
+	Customers int
+	// Вероятность, что заказ сделает уже известный покупатель
+	RepeatRate float64
+	MaxItems   int
+
+	// Доля заведомо невалидных заказов и их виды (по умолчанию все)
+	InvalidRate  float64
+	InvalidKinds []InvalidKind
+
+	// Источник времени для date_created и payment_dt; с фиксированным Now вывод полностью повторяется
+	Now func() time.Time
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Seed:         time.Now().UnixNano(),
+		Dictionaries: DefaultDictionaries(),
+		ZipfS:        1.2,
+		Customers:    1000,
+		RepeatRate:   0.6,
+		MaxItems:     3,
+		Now:          time.Now,
+	}
+}
+
+type customer struct {
+	id      string
+	name    string
+	phone   string
+	email   string
+	city    City
+	zip     string
+	address string
+}
+
+// Random генерирует правдоподобные заказы: бренды и покупатели распределены по Zipf,
+// суммы оплаты сходятся с позициями заказа. С одинаковыми Seed и Now последовательность повторяется
+type Random struct {
+	mu        sync.Mutex
+	cfg       Config
+	rnd       *rand.Rand
+	customers []customer
+}
+
+func NewRandom(cfg Config) (*Random, error) {
+	if cfg.ZipfS <= 1 {
+		return nil, fmt.Errorf("zipf s must be greater than 1, got %v", cfg.ZipfS)
+	}
+	if cfg.Customers <= 0 || cfg.MaxItems <= 0 {
+		return nil, fmt.Errorf("customers and max items must be positive")
+	}
+	if err := cfg.Dictionaries.validate(); err != nil {
+		return nil, err
+	}
+	if len(cfg.InvalidKinds) == 0 {
+		cfg.InvalidKinds = AllInvalidKinds
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &Random{
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(cfg.Seed)),
+	}, nil
+}
+
+func (g *Random) GenerateFakeOrder() *domain.CompleteFakeOrder {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	dict := g.cfg.Dictionaries
+	now := g.cfg.Now()
+	c := g.pickCustomer()
+
+	order := &domain.CompleteFakeOrder{
+		OrderUID:          g.uuid(),
+		TrackNumber:       "WBIL" + strings.ToUpper(g.randomString(10)),
+		Entry:             "WBIL",
+		Locale:            g.choice(dict.Locales),
+		InternalSignature: "",
+		CustomerID:        c.id,
+		DeliveryService:   g.choice(dict.DeliveryServices),
+		ShardKey:          fmt.Sprint(g.rnd.Intn(10)),
+		SmID:              g.rnd.Intn(100),
+		DateCreated:       now.Format(time.RFC3339),
+		OofShard:          fmt.Sprint(g.rnd.Intn(3) + 1),
+		Delivery: domain.FakeDelivery{
+			Name:    c.name,
+			Phone:   c.phone,
+			Zip:     c.zip,
+			City:    c.city.Name,
+			Address: c.address,
+			Region:  c.city.Region,
+			Email:   c.email,
+		},
+	}
+
+	goodsTotal := 0
+	itemCount := g.rnd.Intn(g.cfg.MaxItems) + 1
+	for i := 0; i < itemCount; i++ {
+		item := g.item(order.TrackNumber)
+		goodsTotal += item.TotalPrice
+		order.Items = append(order.Items, item)
+	}
+
+	deliveryCost := 0
+	if order.DeliveryService != "pickup" {
+		deliveryCost = (g.rnd.Intn(10) + 1) * 100
+	}
+	customFee := 0
+	if g.rnd.Intn(5) == 0 {
+		customFee = goodsTotal / 100
+	}
+
+	order.Payment = domain.FakePayment{
+		Transaction:  order.OrderUID,
+		RequestID:    "",
+		Currency:     g.choice(dict.Currencies),
+		Provider:     g.choice(dict.Providers),
+		Amount:       goodsTotal + deliveryCost + customFee,
+		PaymentDt:    int(now.Unix()),
+		Bank:         g.choice(dict.Banks),
+		DeliveryCost: deliveryCost,
+		GoodsTotal:   goodsTotal,
+		CustomFee:    customFee,
+	}
+
+	if g.cfg.InvalidRate > 0 && g.rnd.Float64() < g.cfg.InvalidRate {
+		g.corrupt(order, g.cfg.InvalidKinds[g.rnd.Intn(len(g.cfg.InvalidKinds))])
+	}
+	return order
+}
+
+// pickCustomer с вероятностью RepeatRate возвращает известного покупателя (частые покупатели по Zipf),
+// иначе заводит нового
+func (g *Random) pickCustomer() customer {
+	full := len(g.customers) >= g.cfg.Customers
+	if len(g.customers) > 0 && (full || g.rnd.Float64() < g.cfg.RepeatRate) {
+		return g.customers[g.zipf(len(g.customers))]
+	}
+
+	dict := g.cfg.Dictionaries
+	first, last := g.choice(dict.FirstNames), g.choice(dict.LastNames)
+	city := dict.Cities[g.rnd.Intn(len(dict.Cities))]
+	c := customer{
+		id:      "customer_" + g.randomString(6),
+		name:    first + " " + last,
+		phone:   "+79" + g.randomDigits(9),
+		email:   fmt.Sprintf("%s.%s%d@%s", strings.ToLower(first), strings.ToLower(last), g.rnd.Intn(100), g.choice(dict.EmailDomains)),
+		city:    city,
+		zip:     city.ZipPrefix + g.randomDigits(6-len(city.ZipPrefix)),
+		address: fmt.Sprintf("%s st. %d", g.choice(dict.Streets), g.rnd.Intn(150)+1),
+	}
+	g.customers = append(g.customers, c)
+	return c
+}
+
+// item собирает позицию с ценой со скидкой: total_price = price * (100 - sale) / 100
+func (g *Random) item(trackNumber string) domain.FakeItem {
+	dict := g.cfg.Dictionaries
+	product := dict.Products[g.rnd.Intn(len(dict.Products))]
+	brand := g.zipf(len(dict.Brands))
+	price := product.MinPrice + g.rnd.Intn(product.MaxPrice-product.MinPrice+1)
+	sale := []int{0, 0, 0, 10, 15, 20, 30, 50}[g.rnd.Intn(8)]
+
+	return domain.FakeItem{
+		ChrtID:      9000000 + g.rnd.Intn(1000000),
+		TrackNumber: trackNumber,
+		Price:       price,
+		Rid:         g.randomString(16),
+		Name:        product.Name,
+		Sale:        sale,
+		Size:        product.Sizes[g.rnd.Intn(len(product.Sizes))],
+		TotalPrice:  price * (100 - sale) / 100,
+		NmID:        2000000 + brand*10000 + g.rnd.Intn(10000),
+		Brand:       dict.Brands[brand],
+		Status:      202,
+	}
+}
+
+// zipf возвращает индекс в [0, n), где меньшие индексы встречаются чаще
+func (g *Random) zipf(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return int(rand.NewZipf(g.rnd, g.cfg.ZipfS, 1, uint64(n-1)).Uint64())
+}
+
+func (g *Random) uuid() string {
+	return uuid.Must(uuid.NewRandomFromReader(g.rnd)).String()
+}
+
+func (g *Random) choice(choices []string) string {
+	return choices[g.rnd.Intn(len(choices))]
+}
+
+func (g *Random) randomString(length int) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = chars[g.rnd.Intn(len(chars))]
+	}
+	return string(result)
+}
+
+func (g *Random) randomDigits(length int) string {
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = byte('0' + g.rnd.Intn(10))
+	}
+	return string(result)
+}
+
+// Seed возвращает seed, с которым создан генератор, чтобы прогон можно было повторить
+func (g *Random) Seed() int64 {
+	return g.cfg.Seed
+}
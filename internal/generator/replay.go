@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"L0WB/internal/domain"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Replay по кругу отдает заказы из файла без изменений
+type Replay struct {
+	mu     sync.Mutex
+	orders []domain.CompleteFakeOrder
+	next   int
+}
+
+// LoadReplay читает заказы из JSON-массива или из файла JSON Lines (заказ на строку)
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replay file: %v", err)
+	}
+
+	var orders []domain.CompleteFakeOrder
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &orders); err != nil {
+			return nil, fmt.Errorf("error parsing replay file %s: %v", path, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var order domain.CompleteFakeOrder
+			if err := json.Unmarshal(scanner.Bytes(), &order); err != nil {
+				return nil, fmt.Errorf("error parsing replay file %s line %d: %v", path, line, err)
+			}
+			orders = append(orders, order)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading replay file %s: %v", path, err)
+		}
+	}
+
+	if len(orders) == 0 {
+		return nil, fmt.Errorf("replay file %s has no orders", path)
+	}
+	return &Replay{orders: orders}, nil
+}
+
+func (r *Replay) GenerateFakeOrder() *domain.CompleteFakeOrder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order := cloneOrder(r.orders[r.next%len(r.orders)])
+	r.next++
+	return order
+}
+
+func cloneOrder(order domain.CompleteFakeOrder) *domain.CompleteFakeOrder {
+	order.Items = append([]domain.FakeItem(nil), order.Items...)
+	return &order
+}
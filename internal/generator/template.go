@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"L0WB/internal/domain"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Template копирует заказ-шаблон, подставляя новые идентификаторы и время создания
+type Template struct {
+	mu       sync.Mutex
+	template domain.CompleteFakeOrder
+	rnd      *rand.Rand
+	now      func() time.Time
+}
+
+func LoadTemplate(path string, seed int64) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading template file: %v", err)
+	}
+
+	var template domain.CompleteFakeOrder
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("error parsing template file %s: %v", path, err)
+	}
+	if len(template.Items) == 0 {
+		return nil, fmt.Errorf("template %s has no items", path)
+	}
+
+	return &Template{
+		template: template,
+		rnd:      rand.New(rand.NewSource(seed)),
+		now:      time.Now,
+	}, nil
+}
+
+func (t *Template) GenerateFakeOrder() *domain.CompleteFakeOrder {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	order := cloneOrder(t.template)
+	now := t.now()
+
+	order.OrderUID = uuid.Must(uuid.NewRandomFromReader(t.rnd)).String()
+	order.TrackNumber = order.Entry + strings.ToUpper(t.randomString(10))
+	order.DateCreated = now.Format(time.RFC3339)
+	order.Payment.Transaction = order.OrderUID
+	order.Payment.PaymentDt = int(now.Unix())
+	for i := range order.Items {
+		order.Items[i].TrackNumber = order.TrackNumber
+		order.Items[i].Rid = t.randomString(16)
+	}
+	return order
+}
+
+func (t *Template) randomString(length int) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = chars[t.rnd.Intn(len(chars))]
+	}
+	return string(result)
+}
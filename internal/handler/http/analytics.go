@@ -0,0 +1,159 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+	"time"
+)
+
+func dateRange(from, to og.OptDate) domain.DateRange {
+	return domain.DateRange{
+		From: from.Or(time.Time{}),
+		To:   to.Or(time.Time{}),
+	}
+}
+
+func (h *Handler) GetRevenue(ctx context.Context, params og.GetRevenueParams) (*og.RevenueResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	points, err := h.Analytics.Revenue(ctx, tenant, dateRange(params.From, params.To))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &og.RevenueResponse{
+		Success: true,
+		Points:  make([]og.RevenuePoint, len(points)),
+	}
+	for i, p := range points {
+		res.Points[i] = og.RevenuePoint{
+			Day:          p.Day,
+			Currency:     p.Currency,
+			Provider:     p.Provider,
+			Orders:       p.Orders,
+			Revenue:      p.Revenue,
+			DeliveryCost: p.DeliveryCost,
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) GetTopBrands(ctx context.Context, params og.GetTopBrandsParams) (*og.TopBrandsResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	brands, err := h.Analytics.TopBrands(ctx, tenant, dateRange(params.From, params.To), params.Limit.Or(0))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &og.TopBrandsResponse{
+		Success: true,
+		Brands:  make([]og.BrandSales, len(brands)),
+	}
+	for i, b := range brands {
+		res.Brands[i] = og.BrandSales{
+			Currency: b.Currency,
+			Brand:    b.Brand,
+			Items:    b.Items,
+			Revenue:  b.Revenue,
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) GetTopItems(ctx context.Context, params og.GetTopItemsParams) (*og.TopItemsResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	items, err := h.Analytics.TopItems(ctx, tenant, dateRange(params.From, params.To), params.Limit.Or(0))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &og.TopItemsResponse{
+		Success: true,
+		Items:   make([]og.ItemSales, len(items)),
+	}
+	for i, item := range items {
+		res.Items[i] = og.ItemSales{
+			Currency: item.Currency,
+			NmID:     item.NmID,
+			Name:     item.Name,
+			Brand:    item.Brand,
+			Items:    item.Items,
+			Revenue:  item.Revenue,
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) GetDiscountStats(ctx context.Context, params og.GetDiscountStatsParams) (*og.DiscountStatsResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.Analytics.Discount(ctx, tenant, dateRange(params.From, params.To))
+	if err != nil {
+		return nil, err
+	}
+
+	return &og.DiscountStatsResponse{
+		Success:     true,
+		Items:       stats.Items,
+		AverageSale: stats.AverageSale,
+	}, nil
+}
+
+func (h *Handler) GetDeliveryServiceShare(ctx context.Context, params og.GetDeliveryServiceShareParams) (*og.DeliveryServiceShareResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := h.Analytics.DeliveryServices(ctx, tenant, dateRange(params.From, params.To))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &og.DeliveryServiceShareResponse{
+		Success:  true,
+		Services: make([]og.DeliveryServiceShare, len(shares)),
+	}
+	for i, s := range shares {
+		res.Services[i] = og.DeliveryServiceShare{
+			DeliveryService: s.DeliveryService,
+			Orders:          s.Orders,
+			Share:           s.Share,
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) GetRegionOrders(ctx context.Context, params og.GetRegionOrdersParams) (*og.RegionOrdersResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := h.Analytics.Regions(ctx, tenant, dateRange(params.From, params.To))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &og.RegionOrdersResponse{
+		Success: true,
+		Regions: make([]og.RegionOrders, len(regions)),
+	}
+	for i, r := range regions {
+		res.Regions[i] = og.RegionOrders{
+			Region: r.Region,
+			City:   r.City,
+			Orders: r.Orders,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+	"time"
+)
+
+func (h *Handler) ApplyRetention(ctx context.Context, req *og.ApplyRetentionRequest) (*og.ApplyRetentionResponse, error) {
+	mode, err := domain.ParseRetentionMode(string(req.Mode.Or(og.ApplyRetentionRequestModeArchive)))
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := h.Service.ApplyRetention(ctx, domain.RetentionPolicy{
+		OlderThan: time.Duration(req.OlderThanDays) * 24 * time.Hour,
+		Mode:      mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return applyRetentionResponseFromDomain(report), nil
+}
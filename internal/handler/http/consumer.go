@@ -0,0 +1,199 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"L0WB/internal/kafka"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Запросы к брокерам за состоянием группы
+const consumerStatusTimeout = 10 * time.Second
+
+// GetConsumerStatus отдает назначенные партиции, оффсеты, отставание и ошибки консьюмера.
+// Ошибки запросов к брокерам попадают в поле errors, отчет отдается частично
+func (h *Handler) GetConsumerStatus(ctx context.Context) (*og.ConsumerStatus, error) {
+	if h.Consumer == nil {
+		return nil, domain.ErrConsumerDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, consumerStatusTimeout)
+	defer cancel()
+	status := h.Consumer.Status(ctx)
+
+	res := &og.ConsumerStatus{
+		GroupID:    status.GroupID,
+		ClientID:   status.ClientID,
+		Topics:     status.Topics,
+		Members:    make([]og.ConsumerGroupMember, len(status.Members)),
+		Partitions: make([]og.ConsumerPartitionStatus, len(status.Partitions)),
+		TotalLag:   status.TotalLag,
+		Reader: og.ConsumerReaderStatus{
+			Messages:      status.Reader.Messages,
+			Bytes:         status.Reader.Bytes,
+			Fetches:       status.Reader.Fetches,
+			Dials:         status.Reader.Dials,
+			Rebalances:    status.Reader.Rebalances,
+			Timeouts:      status.Reader.Timeouts,
+			Errors:        status.Reader.Errors,
+			ReadErrors:    status.Reader.ReadErrors,
+			QueueLength:   status.Reader.QueueLength,
+			QueueCapacity: status.Reader.QueueCapacity,
+		},
+		Flow:   convertFlowStatus(status.Flow),
+		Errors: status.Errors,
+	}
+	if status.GroupState != "" {
+		res.GroupState = og.NewOptString(status.GroupState)
+	}
+	for i, m := range status.Members {
+		res.Members[i] = og.ConsumerGroupMember{
+			MemberID:   m.MemberID,
+			ClientID:   m.ClientID,
+			ClientHost: m.ClientHost,
+			Local:      m.Local,
+			Partitions: m.Partitions,
+		}
+	}
+	for i, p := range status.Partitions {
+		ps := og.ConsumerPartitionStatus{
+			Topic:           p.Topic,
+			Partition:       p.Partition,
+			Local:           p.Local,
+			CommittedOffset: p.CommittedOffset,
+			HighWatermark:   p.HighWatermark,
+			Lag:             p.Lag,
+			Processed:       p.Processed,
+		}
+		if p.MemberID != "" {
+			ps.MemberID = og.NewOptString(p.MemberID)
+		}
+		if p.LastOffset != nil {
+			ps.LastOffset = og.NewOptInt64(*p.LastOffset)
+		}
+		if p.LastProcessedAt != nil {
+			ps.LastProcessedAt = og.NewOptDateTime(*p.LastProcessedAt)
+		}
+		if len(p.Errors) > 0 {
+			ps.Errors = og.NewOptConsumerPartitionStatusErrors(p.Errors)
+		}
+		res.Partitions[i] = ps
+	}
+	return res, nil
+}
+
+// ReplayConsumer повторно читает диапазон топика мимо группы консьюмера. Неверный запрос - 400,
+// ошибка чтения - 500 с частичным отчетом, по которому видно, докуда дошло чтение
+func (h *Handler) ReplayConsumer(ctx context.Context, req *og.ConsumerReplayRequest) (og.ReplayConsumerRes, error) {
+	if h.Consumer == nil {
+		return nil, domain.ErrConsumerDisabled
+	}
+
+	replay := kafka.ReplayRequest{
+		Topic:      req.Topic.Or(""),
+		Partitions: req.Partitions,
+		Mode:       string(req.Mode.Or(og.ConsumerReplayRequestModeDryRun)),
+		Limit:      req.Limit.Or(kafka.DefaultReplayLimit),
+	}
+	var err error
+	if replay.From, err = kafka.ParseOffsetSpec(req.From.Or(kafka.OffsetEarliest)); err != nil {
+		return nil, fmt.Errorf("%w: from: %v", kafka.ErrInvalidReplay, err)
+	}
+	if replay.To, err = kafka.ParseOffsetSpec(req.To.Or(kafka.OffsetLatest)); err != nil {
+		return nil, fmt.Errorf("%w: to: %v", kafka.ErrInvalidReplay, err)
+	}
+
+	report, err := h.Consumer.Replay(ctx, replay)
+	if err != nil {
+		if report.Topic == "" {
+			return nil, err
+		}
+		return &og.ConsumerReplayFailure{Error: err.Error(), Report: convertReplayReport(report)}, nil
+	}
+	res := convertReplayReport(report)
+	return &res, nil
+}
+
+// PauseConsumer ставит прием на ручную паузу до ResumeConsumer
+func (h *Handler) PauseConsumer(ctx context.Context) (*og.ConsumerFlowStatus, error) {
+	if h.Consumer == nil {
+		return nil, domain.ErrConsumerDisabled
+	}
+	h.Consumer.Pause()
+	res := convertFlowStatus(h.Consumer.FlowStatus())
+	return &res, nil
+}
+
+func (h *Handler) ResumeConsumer(ctx context.Context) (*og.ConsumerFlowStatus, error) {
+	if h.Consumer == nil {
+		return nil, domain.ErrConsumerDisabled
+	}
+	h.Consumer.Resume()
+	res := convertFlowStatus(h.Consumer.FlowStatus())
+	return &res, nil
+}
+
+// SetConsumerRate меняет ограничение скорости приема; без burst остается текущая емкость корзины
+func (h *Handler) SetConsumerRate(ctx context.Context, req *og.ConsumerRateRequest) (*og.ConsumerFlowStatus, error) {
+	if h.Consumer == nil {
+		return nil, domain.ErrConsumerDisabled
+	}
+	h.Consumer.SetMaxRate(req.MaxRate, req.Burst.Or(h.Consumer.FlowStatus().Burst))
+	res := convertFlowStatus(h.Consumer.FlowStatus())
+	return &res, nil
+}
+
+func convertReplayReport(report kafka.ReplayReport) og.ConsumerReplayReport {
+	res := og.ConsumerReplayReport{
+		Topic:      report.Topic,
+		Handler:    report.Handler,
+		Mode:       report.Mode,
+		Partitions: make([]og.ConsumerReplayPartition, len(report.Partitions)),
+		Read:       report.Read,
+		Valid:      report.Valid,
+		Invalid:    report.Invalid,
+		Applied:    report.Applied,
+		Failed:     report.Failed,
+		Truncated:  report.Truncated,
+	}
+	for i, p := range report.Partitions {
+		res.Partitions[i] = og.ConsumerReplayPartition{
+			Partition:  p.Partition,
+			From:       p.From,
+			To:         p.To,
+			Read:       p.Read,
+			NextOffset: p.NextOffset,
+		}
+	}
+	for _, e := range report.Errors {
+		res.Errors = append(res.Errors, og.ConsumerReplayError{
+			Partition: e.Partition,
+			Offset:    e.Offset,
+			Reason:    e.Reason,
+			Error:     e.Error,
+		})
+	}
+	return res
+}
+
+func convertFlowStatus(flow kafka.FlowStatus) og.ConsumerFlowStatus {
+	res := og.ConsumerFlowStatus{
+		Paused:      flow.Paused,
+		ManualPause: flow.ManualPause,
+		Breaker:     og.ConsumerFlowStatusBreaker(flow.Breaker),
+		MaxRate:     flow.MaxRate,
+		Burst:       flow.Burst,
+	}
+	if flow.PausedAt != nil {
+		res.PausedAt = og.NewOptDateTime(*flow.PausedAt)
+	}
+	if flow.BreakerCause != "" {
+		res.BreakerCause = og.NewOptString(flow.BreakerCause)
+	}
+	if flow.RetryAt != nil {
+		res.RetryAt = og.NewOptDateTime(*flow.RetryAt)
+	}
+	return res
+}
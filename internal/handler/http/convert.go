@@ -3,46 +3,56 @@ package http
 import (
 	"L0WB/internal/domain"
 	og "L0WB/internal/generated/servers/http/ordergen"
+	"github.com/google/uuid"
 )
 
 func getOrderResponseFromDomain(order *domain.Order) *og.GetOrderResponse {
 	res := &og.GetOrderResponse{
 		Success: true,
-		Data: og.Order{
-			OrderUID:    order.ID.String(),
-			TrackNumber: order.TrackNumber,
-			Entry:       order.Entry,
-			Delivery: og.Delivery{
-				Name:    order.Delivery.Name,
-				Phone:   order.Delivery.Phone,
-				Zip:     order.Delivery.Zip,
-				City:    order.Delivery.City,
-				Address: order.Delivery.Address,
-				Region:  order.Delivery.Region,
-				Email:   order.Delivery.Email,
-			},
-			Payment: og.Payment{
-				Transaction:  order.Payment.Transaction,
-				RequestID:    order.Payment.RequestID,
-				Currency:     order.Payment.Currency,
-				Provider:     order.Payment.Provider,
-				Amount:       order.Payment.Amount,
-				PaymentDt:    order.Payment.PaymentDt,
-				Bank:         order.Payment.Bank,
-				DeliveryCost: order.Payment.DeliveryCost,
-				GoodsTotal:   order.Payment.GoodsTotal,
-				CustomFee:    order.Payment.CustomFee,
-			},
-			Items:             ConvertToOGItems(order.Items),
-			Locale:            order.Locale,
-			InternalSignature: order.InternalSignature,
-			CustomerID:        order.CustumerID,
-			DeliveryService:   order.DeliveryService,
-			Shardkey:          order.ShardKey,
-			SmID:              order.SmID,
-			DateCreated:       order.DateCreated,
-			OofShard:          order.OofShard,
+		Data:    orderFromDomain(order),
+	}
+	return res
+}
+
+func orderFromDomain(order *domain.Order) og.Order {
+	res := og.Order{
+		OrderUID:    order.ID.String(),
+		Tenant:      order.Tenant,
+		TrackNumber: order.TrackNumber,
+		Entry:       order.Entry,
+		Delivery: og.Delivery{
+			Name:    order.Delivery.Name,
+			Phone:   order.Delivery.Phone,
+			Zip:     order.Delivery.Zip,
+			City:    order.Delivery.City,
+			Address: order.Delivery.Address,
+			Region:  order.Delivery.Region,
+			Email:   order.Delivery.Email,
 		},
+		Payment: og.Payment{
+			Transaction:  order.Payment.Transaction,
+			RequestID:    order.Payment.RequestID,
+			Currency:     order.Payment.Currency().Code,
+			Provider:     order.Payment.Provider,
+			Amount:       int(order.Payment.Amount.Amount),
+			PaymentDt:    order.Payment.PaymentDt,
+			Bank:         order.Payment.Bank,
+			DeliveryCost: int(order.Payment.DeliveryCost.Amount),
+			GoodsTotal:   int(order.Payment.GoodsTotal.Amount),
+			CustomFee:    int(order.Payment.CustomFee.Amount),
+		},
+		Items:             ConvertToOGItems(order.Items),
+		Locale:            order.Locale,
+		InternalSignature: order.InternalSignature,
+		CustomerID:        order.CustumerID,
+		DeliveryService:   order.DeliveryService,
+		Shardkey:          order.ShardKey,
+		SmID:              order.SmID,
+		DateCreated:       order.DateCreated,
+		OofShard:          order.OofShard,
+	}
+	if order.SourceOrderUID != "" {
+		res.SourceOrderUID = og.NewOptString(order.SourceOrderUID)
 	}
 	return res
 }
@@ -58,12 +68,12 @@ func ConvertToOGItems(domainItems []domain.Item) []og.Item {
 		ogItems[i] = og.Item{
 			ChrtID:      domainItem.ChartID,
 			TrackNumber: domainItem.TrackNumber,
-			Price:       domainItem.Price,
+			Price:       int(domainItem.Price.Amount),
 			Rid:         domainItem.RID,
 			Name:        domainItem.Name,
 			Sale:        domainItem.Sale,
 			Size:        domainItem.Size,
-			TotalPrice:  domainItem.TotalPrice,
+			TotalPrice:  int(domainItem.TotalPrice.Amount),
 			NmID:        domainItem.NmID,
 			Brand:       domainItem.Brand,
 			Status:      domainItem.Status,
@@ -71,3 +81,73 @@ func ConvertToOGItems(domainItems []domain.Item) []og.Item {
 	}
 	return ogItems
 }
+
+func uuidsToStrings(ids []uuid.UUID) []string {
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res
+}
+
+func eraseCustomerResponseFromDomain(report domain.ErasureReport) *og.EraseCustomerResponse {
+	return &og.EraseCustomerResponse{
+		Success:              true,
+		CustomerID:           report.CustomerID,
+		Tenant:               report.Tenant,
+		OrderUids:            uuidsToStrings(report.OrderUIDs),
+		OrdersAffected:       len(report.OrderUIDs),
+		DeliveriesAnonymised: int(report.DeliveriesAnonymised),
+		ArchivedAnonymised:   int(report.ArchivedAnonymised),
+		EventsAnonymised:     int(report.EventsAnonymised),
+		ErasedAt:             report.ErasedAt,
+	}
+}
+
+func applyRetentionResponseFromDomain(report domain.RetentionReport) *og.ApplyRetentionResponse {
+	return &og.ApplyRetentionResponse{
+		Success:        true,
+		Mode:           string(report.Mode),
+		Before:         report.Before,
+		OrdersAffected: len(report.Orders),
+	}
+}
+
+func customerOrdersResponseFromDomain(customerID string, orders []domain.Order) *og.CustomerOrdersResponse {
+	res := &og.CustomerOrdersResponse{
+		Success:    true,
+		CustomerID: customerID,
+		Orders:     make([]og.Order, len(orders)),
+	}
+	for i := range orders {
+		res.Orders[i] = orderFromDomain(&orders[i])
+	}
+	return res
+}
+
+func customerSummaryResponseFromDomain(summary domain.CustomerSummary) *og.CustomerSummaryResponse {
+	res := &og.CustomerSummaryResponse{
+		Success:         true,
+		CustomerID:      summary.CustomerID,
+		Tenant:          summary.Tenant,
+		OrdersCount:     summary.OrdersCount,
+		Spend:           make([]og.CurrencySpend, len(summary.SpendByCurrency)),
+		FirstOrderAt:    summary.FirstOrderAt,
+		LastOrderAt:     summary.LastOrderAt,
+		FavouriteBrands: make([]og.BrandCount, len(summary.FavouriteBrands)),
+	}
+	for i, spend := range summary.SpendByCurrency {
+		res.Spend[i] = og.CurrencySpend{
+			Currency: spend.Currency,
+			Total:    spend.Total,
+			Orders:   spend.Orders,
+		}
+	}
+	for i, brand := range summary.FavouriteBrands {
+		res.FavouriteBrands[i] = og.BrandCount{
+			Brand: brand.Brand,
+			Items: brand.Items,
+		}
+	}
+	return res
+}
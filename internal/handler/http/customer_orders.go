@@ -0,0 +1,35 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+)
+
+func (h *Handler) ListCustomerOrders(ctx context.Context, params og.ListCustomerOrdersParams) (*og.CustomerOrdersResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := h.Service.ListCustomerOrders(ctx, domain.CustomerKey{Tenant: tenant, CustomerID: params.ID}, params.Limit.Or(0), params.Offset.Or(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return customerOrdersResponseFromDomain(params.ID, orders), nil
+}
+
+func (h *Handler) GetCustomerSummary(ctx context.Context, params og.GetCustomerSummaryParams) (*og.CustomerSummaryResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := h.Service.GetCustomerSummary(ctx, domain.CustomerKey{Tenant: tenant, CustomerID: params.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return customerSummaryResponseFromDomain(summary), nil
+}
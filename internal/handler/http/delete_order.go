@@ -0,0 +1,28 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+	"fmt"
+)
+
+func (h *Handler) DeleteOrder(ctx context.Context, req *og.DeleteOrderRequest, params og.DeleteOrderParams) (*og.DeleteOrderResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	id, _, err := domain.ParseOrderUID(req.OrderUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order uid")
+	}
+
+	if err := h.Service.DeleteOrder(ctx, domain.OrderKey{Tenant: tenant, OrderUID: id}); err != nil {
+		return nil, err
+	}
+
+	return &og.DeleteOrderResponse{
+		Success:  true,
+		OrderUID: id.String(),
+	}, nil
+}
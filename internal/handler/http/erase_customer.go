@@ -0,0 +1,21 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+)
+
+func (h *Handler) EraseCustomer(ctx context.Context, req *og.EraseCustomerRequest, params og.EraseCustomerParams) (*og.EraseCustomerResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := h.Service.EraseCustomer(ctx, domain.CustomerKey{Tenant: tenant, CustomerID: req.CustomerID})
+	if err != nil {
+		return nil, err
+	}
+
+	return eraseCustomerResponseFromDomain(report), nil
+}
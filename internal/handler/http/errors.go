@@ -0,0 +1,35 @@
+package http
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/kafka"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"net/http"
+)
+
+// ErrorHandler отдает 404 на ненайденные заказы и покупателей и выключенные генератор и consumer,
+// 400 на неверную витрину и запрос повторного чтения,
+// остальные ошибки - как ogen по умолчанию
+func ErrorHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrCustomerNotFound),
+		errors.Is(err, domain.ErrGeneratorDisabled),
+		errors.Is(err, domain.ErrConsumerDisabled):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrInvalidTenant),
+		errors.Is(err, kafka.ErrInvalidReplay):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		ogenerrors.DefaultErrorHandler(ctx, w, r, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error_message": err.Error()})
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+)
+
+func (h *Handler) GenerateOrders(ctx context.Context, req *og.GenerateOrdersRequest) (*og.GenerateOrdersResponse, error) {
+	generated, err := h.Service.GenerateFakeOrdersFromKafka(ctx, string(req.Strategy.Or("")), req.Count.Or(1), req.Rate.Or(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return &og.GenerateOrdersResponse{
+		Success:   true,
+		Generated: generated,
+	}, nil
+}
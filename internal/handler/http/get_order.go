@@ -1,24 +1,43 @@
 package http
 
 import (
+	"L0WB/internal/domain"
 	og "L0WB/internal/generated/servers/http/ordergen"
 	"context"
 	"fmt"
-	"github.com/google/uuid"
 )
 
-func (h *Handler) GetOrder(ctx context.Context, req *og.GetOrderRequest) (*og.GetOrderResponse, error) {
-
-	id, err := uuid.Parse(req.OrderUID)
+func (h *Handler) GetOrder(ctx context.Context, req *og.GetOrderRequest, params og.GetOrderParams) (*og.GetOrderResponse, error) {
+	tenant, err := requestTenant(params.XTenant)
+	if err != nil {
+		return nil, err
+	}
+	id, _, err := domain.ParseOrderUID(req.OrderUID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid order uid")
 	}
 
-	order, err := h.Service.GetOrder(ctx, id)
+	order, err := h.Service.GetOrder(ctx, domain.OrderKey{Tenant: tenant, OrderUID: id})
 	if err != nil {
 		return nil, err
 	}
 
+	//Пересчет сумм в запрошенную валюту
+	if currency, ok := req.Currency.Get(); ok {
+		converted, conv, err := h.Converter.ConvertOrder(ctx, order, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := getOrderResponseFromDomain(converted)
+		resp.Conversion = og.NewOptConversion(og.Conversion{
+			From: conv.From.Code,
+			To:   conv.To.Code,
+			Rate: conv.Rate,
+		})
+		return resp, nil
+	}
+
 	resp := getOrderResponseFromDomain(order)
 	return resp, nil
 }
@@ -3,21 +3,69 @@ package http
 import (
 	"L0WB/internal/domain"
 	og "L0WB/internal/generated/servers/http/ordergen"
+	"L0WB/internal/kafka"
 	"context"
-	"github.com/google/uuid"
 )
 
 type IService interface {
-	GetOrder(ctx context.Context, orderUID uuid.UUID) (*domain.Order, error)
+	GetOrder(ctx context.Context, key domain.OrderKey) (*domain.Order, error)
+	DeleteOrder(ctx context.Context, key domain.OrderKey) error
+	EraseCustomer(ctx context.Context, customer domain.CustomerKey) (domain.ErasureReport, error)
+	ApplyRetention(ctx context.Context, policy domain.RetentionPolicy) (domain.RetentionReport, error)
+	ListCustomerOrders(ctx context.Context, customer domain.CustomerKey, limit, offset int) ([]domain.Order, error)
+	GetCustomerSummary(ctx context.Context, customer domain.CustomerKey) (domain.CustomerSummary, error)
+	GenerateFakeOrdersFromKafka(ctx context.Context, strategy string, count int, rate float64) (int, error)
+}
+
+type IAnalyticsService interface {
+	Revenue(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RevenuePoint, error)
+	TopBrands(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.BrandSales, error)
+	TopItems(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.ItemSales, error)
+	Discount(ctx context.Context, tenant string, dr domain.DateRange) (domain.DiscountStats, error)
+	DeliveryServices(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.DeliveryServiceShare, error)
+	Regions(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RegionOrders, error)
+}
+
+type IReconciliationService interface {
+	Scan(ctx context.Context, batchSize int) (domain.ScanReport, error)
+	Report(ctx context.Context, limit, offset int) (domain.MismatchReport, error)
+}
+
+type ICurrencyConverter interface {
+	ConvertOrder(ctx context.Context, order *domain.Order, to string) (*domain.Order, domain.Conversion, error)
+}
+
+// IConsumerAdmin - служебные операции консьюмера Kafka
+type IConsumerAdmin interface {
+	Status(ctx context.Context) kafka.ConsumerStatus
+	Replay(ctx context.Context, req kafka.ReplayRequest) (kafka.ReplayReport, error)
+	Pause()
+	Resume()
+	SetMaxRate(rate float64, burst int)
+	FlowStatus() kafka.FlowStatus
 }
 
 type Handler struct {
-	Service IService
+	Service        IService
+	Analytics      IAnalyticsService
+	Reconciliation IReconciliationService
+	Converter      ICurrencyConverter
+	// nil на экземплярах без consumer
+	Consumer IConsumerAdmin
 	og.UnimplementedHandler
 }
 
-func NewHandler(service IService) *Handler {
+func NewHandler(service IService, analytics IAnalyticsService, reconciliation IReconciliationService, converter ICurrencyConverter, consumer IConsumerAdmin) *Handler {
 	return &Handler{
-		Service: service,
+		Service:        service,
+		Analytics:      analytics,
+		Reconciliation: reconciliation,
+		Converter:      converter,
+		Consumer:       consumer,
 	}
 }
+
+// requestTenant - витрина запроса из заголовка X-Tenant, без заголовка - витрина по умолчанию
+func requestTenant(header og.OptString) (string, error) {
+	return domain.ParseTenant(header.Or(""))
+}
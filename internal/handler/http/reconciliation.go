@@ -0,0 +1,54 @@
+package http
+
+import (
+	og "L0WB/internal/generated/servers/http/ordergen"
+	"context"
+)
+
+func (h *Handler) GetReconciliationReport(ctx context.Context, params og.GetReconciliationReportParams) (*og.ReconciliationReportResponse, error) {
+	report, err := h.Reconciliation.Report(ctx, params.Limit.Or(0), params.Offset.Or(0))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &og.ReconciliationReportResponse{
+		Success:    true,
+		Total:      report.Total,
+		ByRule:     make([]og.MismatchRuleCount, len(report.ByRule)),
+		Mismatches: make([]og.Mismatch, len(report.Mismatches)),
+	}
+	for i, rc := range report.ByRule {
+		res.ByRule[i] = og.MismatchRuleCount{
+			Rule:   string(rc.Rule),
+			Orders: rc.Orders,
+		}
+	}
+	for i, m := range report.Mismatches {
+		res.Mismatches[i] = og.Mismatch{
+			OrderUID:   m.OrderUID.String(),
+			Tenant:     m.Tenant,
+			Rule:       string(m.Rule),
+			Expected:   m.Expected,
+			Actual:     m.Actual,
+			Source:     string(m.Source),
+			DetectedAt: m.DetectedAt,
+		}
+		if m.Detail != "" {
+			res.Mismatches[i].Detail = og.NewOptString(m.Detail)
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) RunReconciliationScan(ctx context.Context) (*og.ReconciliationScanResponse, error) {
+	report, err := h.Reconciliation.Scan(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &og.ReconciliationScanResponse{
+		Success:    true,
+		Scanned:    report.Scanned,
+		Mismatches: report.Mismatches,
+	}, nil
+}
@@ -2,104 +2,374 @@ package kafka
 
 import (
 	"L0WB/internal/domain"
+	"L0WB/internal/envelope"
+	"L0WB/internal/logger"
+	"L0WB/internal/metrics"
 	"L0WB/internal/service"
 	"context"
-	"github.com/google/uuid"
-	"github.com/ogen-go/ogen/json"
+	"fmt"
 	"github.com/segmentio/kafka-go"
-	"log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// Пауза перед повтором несохраненного сообщения растет вдвое от min до max
+const (
+	saveRetryMinDelay = time.Second
+	saveRetryMaxDelay = 30 * time.Second
+)
+
 type OrderConsumer struct {
-	reader  *kafka.Reader
-	service *service.Service
-	topic   string
+	reader   *kafka.Reader
+	client   *kafka.Client
+	brokers  []string
+	security *Security
+	groupID  string
+	clientID string
+	progress *progressTracker
+	flow     *flowControl
+	service  *service.Service
+	codecs   *envelope.Codecs
+	topics   []string
+	routes   []route
+	tenants  tenantResolver
+	handlers map[string]messageHandler
+	logger   *slog.Logger
+}
+
+// messageHandler обрабатывает сообщение витрины tenant; reason - причина ошибки для метрик
+type messageHandler func(ctx context.Context, msg kafka.Message, tenant string) (reason string, err error)
+
+type ConsumerConfig struct {
+	Brokers []string
+	// Топики списком и/или регулярным выражением; шаблон разворачивается в список топиков кластера при старте
+	Topics       []string
+	TopicPattern string
+	GroupID      string
+	// Идентификатор клиента в группе; по умолчанию order-service-<host>-<pid>
+	ClientID string
+	// Правила "<regexp>=<handler>" (orders, status, cancellation); топики без правила - заказы
+	Routes []string
+	// Витрина по имени топика
+	TopicTenants map[string]string
+	Flow         FlowConfig
+	Security     SecurityConfig
 }
 
-func NewOrderConsumer(brokers []string, topic string, groupID string, service *service.Service) *OrderConsumer {
+func NewOrderConsumer(ctx context.Context, cfg ConsumerConfig, service *service.Service, codecs *envelope.Codecs, logger *slog.Logger) (*OrderConsumer, error) {
+	security, err := NewSecurity(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := parseRoutes(cfg.Routes)
+	if err != nil {
+		return nil, err
+	}
+
+	var pattern *regexp.Regexp
+	if cfg.TopicPattern != "" {
+		if pattern, err = regexp.Compile(cfg.TopicPattern); err != nil {
+			return nil, fmt.Errorf("invalid topic pattern: %v", err)
+		}
+	}
+	topics, err := resolveTopics(ctx, cfg.Brokers, cfg.Topics, pattern, security)
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = defaultClientID()
+	}
+	dialer := security.dialer()
+	dialer.ClientID = clientID
+
 	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:        brokers,
-		Topic:          topic,
-		GroupID:        groupID,
+		Brokers:        cfg.Brokers,
+		GroupTopics:    topics,
+		GroupID:        cfg.GroupID,
+		Dialer:         dialer,
 		MinBytes:       10e3,
 		MaxBytes:       10e6,
 		MaxWait:        1 * time.Second,
 		CommitInterval: time.Second,
 	})
 
-	return &OrderConsumer{
-		reader:  reader,
-		service: service,
-		topic:   topic,
+	c := &OrderConsumer{
+		reader:   reader,
+		client:   newClient(cfg.Brokers, security),
+		brokers:  cfg.Brokers,
+		security: security,
+		groupID:  cfg.GroupID,
+		clientID: clientID,
+		progress: newProgressTracker(),
+		flow:     newFlowControl(cfg.Flow),
+		service:  service,
+		codecs:   codecs,
+		topics:   topics,
+		routes:   routes,
+		tenants:  tenantResolver{topicTenants: cfg.TopicTenants, pattern: pattern},
+		logger:   logger.With("component", "consumer", "group_id", cfg.GroupID),
+	}
+	c.handlers = map[string]messageHandler{
+		HandlerOrders:       c.handleOrder,
+		HandlerStatus:       c.handleStatus,
+		HandlerCancellation: c.handleCancellation,
+	}
+
+	for _, topic := range topics {
+		c.logger.Info("subscribed to topic", "topic", topic, "handler", handlerFor(routes, topic))
 	}
+	return c, nil
 }
 
 func (c *OrderConsumer) Consume(ctx context.Context) {
-	log.Printf("Starting Kafka consumer for topic: %s", c.reader.Config().Topic)
+	c.logger.InfoContext(ctx, "starting kafka consumer")
+	go c.flow.watch(ctx, c.logger)
 
 	for {
 		select {
 		case <-ctx.Done():
-			log.Println("Stopping Kafka consumer")
+			c.logger.Info("stopping kafka consumer")
 			return
 
 		default:
-			msg, err := c.reader.ReadMessage(ctx)
+			//Пауза и ограничение скорости: новые сообщения не берутся, группа продолжает heartbeat
+			if err := c.flow.wait(ctx); err != nil {
+				continue
+			}
+			msg, err := c.reader.FetchMessage(ctx)
 			if err != nil {
-				log.Printf("Error reading message: %v", err)
+				if ctx.Err() != nil {
+					continue
+				}
+				metrics.MessagesFailed.WithLabelValues(strings.Join(c.topics, ","), metrics.ReasonRead).Inc()
+				c.progress.readError()
+				c.logger.ErrorContext(ctx, "error reading message", "error", err)
 				continue
 			}
+			metrics.ConsumerLag.WithLabelValues(msg.Topic, strconv.Itoa(msg.Partition)).
+				Set(float64(msg.HighWaterMark - msg.Offset - 1))
 
-			log.Printf("Received message: offset=%d", msg.Offset)
-			c.processMessage(ctx, msg)
+			if !c.processWithRetry(ctx, msg) {
+				continue
+			}
+			//Начатое сообщение дообрабатывается и коммитится даже после сигнала остановки
+			if err := c.reader.CommitMessages(context.WithoutCancel(ctx), msg); err != nil {
+				c.logger.ErrorContext(ctx, "error committing offset", "offset", msg.Offset, "error", err)
+			}
 		}
 	}
 }
-func (c *OrderConsumer) processMessage(ctx context.Context, msg kafka.Message) {
-	log.Printf("Received message: %s", string(msg.Value))
 
-	var fakeOrder domain.CompleteFakeOrder
-	if err := json.Unmarshal(msg.Value, &fakeOrder); err != nil {
-		log.Printf("Error unmarshaling as CompleteFakeOrder: %v", err)
-		return
+// messageCorrelationID берет correlation id продюсера, а без него - координаты сообщения
+func messageCorrelationID(msg kafka.Message) string {
+	if id := (headerCarrier{msg: &msg}).Get(correlationIDHeader); id != "" {
+		return id
 	}
-	log.Printf("CompleteFakeOrder: %+v", fakeOrder)
+	return fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+}
 
-	order := convertFakeToDomainOrder(fakeOrder)
+// processWithRetry обрабатывает сообщение, пока оно не сохранится или не окажется битым.
+// После ошибки сохранения оффсет не коммитится: то же сообщение повторяется после паузы
+// и ожидания выключателя, так что недоступная БД не теряет заказы. false - consumer
+// остановлен раньше, чем сообщение сохранилось; после перезапуска оно будет прочитано снова
+func (c *OrderConsumer) processWithRetry(ctx context.Context, msg kafka.Message) bool {
+	processCtx := context.WithoutCancel(ctx)
+	delay := saveRetryMinDelay
+	for {
+		if c.processMessage(processCtx, msg) != metrics.ReasonSave {
+			return true
+		}
+		c.logger.WarnContext(ctx, "message not saved, retrying", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, "retry_in", delay)
 
-	if err := c.service.SaveOrderFromKafka(ctx, order); err != nil {
-		log.Printf("Error processing order: %v", err)
-		return
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, saveRetryMaxDelay)
+		if err := c.flow.wait(ctx); err != nil {
+			return false
+		}
+	}
+}
+
+// processMessage обрабатывает сообщение и возвращает причину ошибки для метрик, "" - успех
+func (c *OrderConsumer) processMessage(ctx context.Context, msg kafka.Message) string {
+	headers := headerCarrier{msg: &msg}
+	handlerName := handlerFor(c.routes, msg.Topic)
+
+	//Продолжаем трассу продюсера из заголовков сообщения
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headers)
+	ctx = logger.WithCorrelationID(ctx, messageCorrelationID(msg))
+	ctx, span := tracer.Start(ctx, msg.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(msg.Partition)),
+			semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			attribute.String("consumer.handler", handlerName),
+		),
+	)
+	defer span.End()
+
+	c.logger.DebugContext(ctx, "received message",
+		"topic", msg.Topic,
+		"handler", handlerName,
+		"partition", msg.Partition,
+		"offset", msg.Offset,
+		"key", string(msg.Key),
+		"size", len(msg.Value),
+		"schema_version", headers.Get(HeaderSchemaVersion),
+		"producer_id", headers.Get(HeaderProducerID),
+	)
+
+	reason, err := c.handle(ctx, msg, handlerName)
+	c.progress.record(msg, reason)
+	c.flow.observe(reason == metrics.ReasonSave)
+	if err != nil && reason == metrics.ReasonOrderNotFound {
+		metrics.MessagesFailed.WithLabelValues(msg.Topic, reason).Inc()
+		c.logger.InfoContext(ctx, "status update parked until the order is saved", "topic", msg.Topic, "offset", msg.Offset, "error", err)
+		return reason
+	}
+	if err != nil {
+		metrics.MessagesFailed.WithLabelValues(msg.Topic, reason).Inc()
+		span.SetStatus(codes.Error, err.Error())
+		c.logger.ErrorContext(ctx, "error processing message", "topic", msg.Topic, "handler", handlerName, "offset", msg.Offset, "error", err)
+		return reason
+	}
+	metrics.MessagesProcessed.WithLabelValues(msg.Topic).Inc()
+	return ""
+}
+
+func (c *OrderConsumer) handle(ctx context.Context, msg kafka.Message, handlerName string) (string, error) {
+	tenant, err := c.tenants.resolve(msg.Topic, headerCarrier{msg: &msg}.Get(HeaderTenant))
+	if err != nil {
+		return metrics.ReasonUnmarshal, err
 	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("tenant", tenant))
+	return c.handlers[handlerName](ctx, msg, tenant)
+}
+
+func (c *OrderConsumer) handleOrder(ctx context.Context, msg kafka.Message, tenant string) (string, error) {
+	order, err := c.decodeOrder(ctx, msg)
+	if err != nil {
+		return metrics.ReasonUnmarshal, fmt.Errorf("invalid order message: %w", err)
+	}
+	order.Tenant = tenant
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("order.uid", order.ID.String()))
+
+	if err := c.saveOrder(ctx, order); err != nil {
+		return metrics.ReasonSave, fmt.Errorf("order %s: %w", order.ID, err)
+	}
+	c.logger.InfoContext(ctx, "order processed", "order_uid", order.ID, "tenant", tenant)
+	return "", nil
+}
+
+// decodeOrder выбирает кодек по content_type, разбирает конверт и приводит заказ к последней версии схемы
+func (c *OrderConsumer) decodeOrder(ctx context.Context, msg kafka.Message) (*domain.Order, error) {
+	_, span := tracer.Start(ctx, "order.validate")
+	defer span.End()
+
+	order, err := c.decode(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return order, nil
+}
+
+func (c *OrderConsumer) decode(ctx context.Context, msg kafka.Message) (*domain.Order, error) {
+	codec, err := c.codecs.ByContentType(headerCarrier{msg: &msg}.Get(HeaderContentType))
+	if err != nil {
+		return nil, err
+	}
+	env, err := codec.Decode(msg.Value)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Shims) > 0 {
+		shims := make([]string, len(env.Shims))
+		for i, shim := range env.Shims {
+			shims[i] = shim.String()
+			metrics.CompatShims.WithLabelValues(msg.Topic, shim.Kind).Inc()
+		}
+		c.logger.InfoContext(ctx, "compatibility shims applied", "offset", msg.Offset, "shims", shims)
+	}
+	if env.EventType != domain.OrderCreated {
+		return nil, fmt.Errorf("unsupported event type %q", env.EventType)
+	}
+
+	orderV2, err := envelope.Upcast(env)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.DebugContext(ctx, "order decoded", "order_uid", orderV2.OrderUID, "schema_version", env.SchemaVersion,
+		"content_type", codec.ContentType(), "delivery", orderV2.Delivery)
+
+	return convertToDomainOrder(orderV2)
+}
 
-	log.Printf("Order processed successfully: %s", order.ID)
+func (c *OrderConsumer) saveOrder(ctx context.Context, order *domain.Order) error {
+	ctx, span := tracer.Start(ctx, "SaveOrder")
+	defer span.End()
+
+	if err := c.service.SaveOrderFromKafka(ctx, order); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
 }
 
-func convertFakeToDomainOrder(fake domain.CompleteFakeOrder) *domain.Order {
+func convertToDomainOrder(fake *envelope.OrderV2) (*domain.Order, error) {
+	orderUID, mapped, err := domain.ParseOrderUID(fake.OrderUID)
+	if err != nil {
+		return nil, err
+	}
+	if fake.CreatedAt <= 0 {
+		return nil, fmt.Errorf("invalid created_at %d", fake.CreatedAt)
+	}
+	if len(fake.Items) == 0 {
+		return nil, fmt.Errorf("order %s has no items", fake.OrderUID)
+	}
+
+	//Неизвестная валюта не отклоняет заказ: она попадает в отчет сверки
+	currency := domain.CurrencyOf(fake.Payment.Currency)
+	money := func(amount int) domain.Money {
+		return domain.Money{Amount: int64(amount), Currency: currency}
+	}
+
 	// Преобразуем items
 	var items []domain.Item
 	for _, fakeItem := range fake.Items {
 		items = append(items, domain.Item{
-			ChartID:     fakeItem.ChrtID,
+			ChartID:     fakeItem.ChartID,
 			TrackNumber: fakeItem.TrackNumber,
-			Price:       fakeItem.Price,
+			Price:       money(fakeItem.Price),
 			RID:         fakeItem.Rid,
 			Name:        fakeItem.Name,
 			Sale:        fakeItem.Sale,
 			Size:        fakeItem.Size,
-			TotalPrice:  fakeItem.TotalPrice,
+			TotalPrice:  money(fakeItem.TotalPrice),
 			NmID:        fakeItem.NmID,
 			Brand:       fakeItem.Brand,
 			Status:      fakeItem.Status,
 		})
 	}
 
-	// Создаем UUID из строки
-	orderUID, _ := uuid.Parse(fake.OrderUID)
-	dateCreated, _ := time.Parse(time.RFC3339, fake.DateCreated)
-
-	return &domain.Order{
+	order := &domain.Order{
 		ID:                orderUID,
 		TrackNumber:       fake.TrackNumber,
 		Entry:             fake.Entry,
@@ -109,7 +379,7 @@ func convertFakeToDomainOrder(fake domain.CompleteFakeOrder) *domain.Order {
 		DeliveryService:   fake.DeliveryService,
 		ShardKey:          fake.ShardKey,
 		SmID:              fake.SmID,
-		DateCreated:       dateCreated,
+		DateCreated:       time.UnixMilli(fake.CreatedAt).UTC(),
 		OofShard:          fake.OofShard,
 		Delivery: domain.Delivery{
 			Name:    fake.Delivery.Name,
@@ -123,19 +393,48 @@ func convertFakeToDomainOrder(fake domain.CompleteFakeOrder) *domain.Order {
 		Payment: domain.Payment{
 			Transaction:  fake.Payment.Transaction,
 			RequestID:    fake.Payment.RequestID,
-			Currency:     fake.Payment.Currency,
 			Provider:     fake.Payment.Provider,
-			Amount:       fake.Payment.Amount,
+			Amount:       money(fake.Payment.Amount),
 			PaymentDt:    fake.Payment.PaymentDt,
 			Bank:         fake.Payment.Bank,
-			DeliveryCost: fake.Payment.DeliveryCost,
-			GoodsTotal:   fake.Payment.GoodsTotal,
-			CustomFee:    fake.Payment.CustomFee,
+			DeliveryCost: money(fake.Payment.DeliveryCost),
+			GoodsTotal:   money(fake.Payment.GoodsTotal),
+			CustomFee:    money(fake.Payment.CustomFee),
 		},
 		Items: items,
 	}
+	//Не-UUID order_uid сохраняется рядом с производным ключом
+	if mapped {
+		order.SourceOrderUID = fake.OrderUID
+	}
+	return order, nil
+}
+
+// Pause останавливает прием новых сообщений до Resume; начатое сообщение дообрабатывается
+func (c *OrderConsumer) Pause() {
+	if c.flow.pause() {
+		c.logger.Warn("consumer paused")
+	}
+}
+
+// Resume снимает ручную паузу и сбрасывает выключатель
+func (c *OrderConsumer) Resume() {
+	c.flow.resume()
+	c.logger.Info("consumer resumed")
+}
+
+// SetMaxRate меняет ограничение скорости приема; rate <= 0 снимает ограничение
+func (c *OrderConsumer) SetMaxRate(rate float64, burst int) {
+	c.flow.setRate(rate, burst)
+	c.logger.Info("consumer rate limit changed", "max_rate", rate, "burst", burst)
+}
+
+// FlowStatus возвращает состояние паузы, выключателя и ограничения скорости
+func (c *OrderConsumer) FlowStatus() FlowStatus {
+	return c.flow.status()
 }
 
+// Close фиксирует накопленные оффсеты и закрывает reader
 func (c *OrderConsumer) Close() error {
 	return c.reader.Close()
 }
@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/envelope"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"strconv"
+)
+
+// EventSchemaVersion - версия схемы событий заказа из outbox
+const EventSchemaVersion = "1"
+
+// EventProducer публикует события заказов из outbox. Запись синхронная с acks=all:
+// событие считается опубликованным только после подтверждения всех реплик
+type EventProducer struct {
+	writer     *kafka.Writer
+	topic      string
+	producerID string
+}
+
+func NewEventProducer(cfg ProducerConfig) (*EventProducer, error) {
+	compression, err := parseCompression(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	security, err := NewSecurity(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+
+	producerID := cfg.ProducerID
+	if producerID == "" {
+		producerID, _ = os.Hostname()
+	}
+
+	return &EventProducer{
+		writer: &kafka.Writer{
+			Addr:      kafka.TCP(cfg.Brokers...),
+			Topic:     cfg.Topic,
+			Transport: security.transport(),
+			//Ключ - order_uid: события одного заказа читаются по порядку
+			Balancer:     &kafka.Murmur2Balancer{},
+			BatchSize:    cfg.BatchSize,
+			BatchTimeout: cfg.BatchTimeout,
+			RequiredAcks: kafka.RequireAll,
+			Compression:  compression,
+		},
+		topic:      cfg.Topic,
+		producerID: producerID,
+	}, nil
+}
+
+// PublishEvents записывает события одним вызовом writer'а. Заголовок event_id (id строки outbox)
+// позволяет получателям отбрасывать повторы при доставке at-least-once
+func (p *EventProducer) PublishEvents(ctx context.Context, events []domain.OutboxEvent) error {
+	ctx, span := tracer.Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(p.topic),
+			semconv.MessagingBatchMessageCount(len(events)),
+		),
+	)
+	defer span.End()
+
+	msgs := make([]kafka.Message, len(events))
+	for i, e := range events {
+		msgs[i] = kafka.Message{
+			Key:   []byte(e.OrderUID.String()),
+			Value: e.Payload,
+			Headers: []kafka.Header{
+				{Key: HeaderEventID, Value: []byte(strconv.FormatInt(e.ID, 10))},
+				{Key: HeaderEventType, Value: []byte(e.Type)},
+				{Key: HeaderSchemaVersion, Value: []byte(EventSchemaVersion)},
+				{Key: HeaderContentType, Value: []byte(envelope.ContentTypeJSON)},
+				{Key: HeaderProducerID, Value: []byte(p.producerID)},
+				{Key: HeaderTenant, Value: []byte(e.Tenant)},
+			},
+		}
+	}
+
+	if err := p.writer.WriteMessages(ctx, msgs...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		var writeErrs kafka.WriteErrors
+		if errors.As(err, &writeErrs) {
+			return fmt.Errorf("%d of %d events not published: %w", writeErrs.Count(), len(msgs), err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (p *EventProducer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,324 @@
+package kafka
+
+import (
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Состояния автоматического выключателя консьюмера
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// Причины срабатывания выключателя
+const (
+	CausePoolSaturated = "db_pool_saturated"
+	CauseErrorRate     = "error_rate"
+)
+
+const flowCheckInterval = time.Second
+
+// FlowConfig - ограничение приема сообщений: скорость (token bucket) и выключатель,
+// который ставит консьюмер на паузу при насыщении пула БД или всплеске ошибок сохранения
+type FlowConfig struct {
+	// Сообщений в секунду, 0 - без ограничения; Burst - емкость корзины
+	MaxRate float64
+	Burst   int
+	// Доля занятых соединений пула, при которой прием останавливается, 0 - не проверять
+	PoolSaturation float64
+	PoolUsage      func() float64
+	// Доля ошибок сохранения за окно ErrorWindow (не меньше MinMessages сообщений), 0 - не проверять
+	ErrorRate   float64
+	ErrorWindow time.Duration
+	MinMessages int
+	// Пауза выключателя до пробного возобновления
+	Cooldown time.Duration
+}
+
+// FlowStatus - состояние паузы, выключателя и ограничения скорости
+type FlowStatus struct {
+	Paused       bool       `json:"paused"`
+	ManualPause  bool       `json:"manual_pause"`
+	PausedAt     *time.Time `json:"paused_at,omitempty"`
+	Breaker      string     `json:"breaker"`
+	BreakerCause string     `json:"breaker_cause,omitempty"`
+	RetryAt      *time.Time `json:"retry_at,omitempty"`
+	MaxRate      float64    `json:"max_rate"`
+	Burst        int        `json:"burst"`
+}
+
+// flowControl решает, можно ли брать следующее сообщение. Ручная пауза действует до Resume,
+// выключатель открывается на Cooldown, затем пропускает сообщения в полуоткрытом состоянии
+// и закрывается, если за окно ошибок не стало больше порога
+type flowControl struct {
+	cfg    FlowConfig
+	bucket *tokenBucket
+
+	mu           sync.Mutex
+	changed      chan struct{}
+	manual       bool
+	pausedAt     time.Time
+	breaker      string
+	cause        string
+	retryAt      time.Time
+	windowStart  time.Time
+	total, fails int
+}
+
+func newFlowControl(cfg FlowConfig) *flowControl {
+	if cfg.ErrorWindow <= 0 {
+		cfg.ErrorWindow = time.Minute
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+	f := &flowControl{
+		cfg:         cfg,
+		bucket:      newTokenBucket(cfg.MaxRate, cfg.Burst),
+		changed:     make(chan struct{}),
+		breaker:     BreakerClosed,
+		windowStart: time.Now(),
+	}
+	f.publish()
+	return f
+}
+
+// wait блокируется на паузе и до появления токена в корзине
+func (f *flowControl) wait(ctx context.Context) error {
+	for {
+		f.mu.Lock()
+		paused, changed := f.pausedLocked(), f.changed
+		f.mu.Unlock()
+		if !paused {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+	return f.bucket.wait(ctx)
+}
+
+func (f *flowControl) pausedLocked() bool {
+	return f.manual || f.breaker == BreakerOpen
+}
+
+// notifyLocked будит ожидающий wait и обновляет метрику паузы
+func (f *flowControl) notifyLocked() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+	if f.pausedLocked() {
+		if f.pausedAt.IsZero() {
+			f.pausedAt = time.Now()
+		}
+		metrics.ConsumerPaused.Set(1)
+	} else {
+		f.pausedAt = time.Time{}
+		metrics.ConsumerPaused.Set(0)
+	}
+}
+
+func (f *flowControl) publish() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notifyLocked()
+}
+
+// pause ставит ручную паузу; false - консьюмер уже на ручной паузе
+func (f *flowControl) pause() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.manual {
+		return false
+	}
+	f.manual = true
+	f.notifyLocked()
+	return true
+}
+
+// resume снимает ручную паузу и закрывает выключатель: если причина не ушла, он сработает снова
+func (f *flowControl) resume() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.manual = false
+	f.breaker, f.cause = BreakerClosed, ""
+	f.resetWindowLocked(time.Now())
+	f.notifyLocked()
+}
+
+func (f *flowControl) setRate(rate float64, burst int) {
+	f.mu.Lock()
+	f.cfg.MaxRate, f.cfg.Burst = rate, burst
+	f.mu.Unlock()
+	f.bucket.set(rate, burst)
+}
+
+// observe учитывает результат обработки; failed - ошибка сохранения
+func (f *flowControl) observe(failed bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.total++
+	if failed {
+		f.fails++
+	}
+}
+
+func (f *flowControl) resetWindowLocked(now time.Time) {
+	f.windowStart, f.total, f.fails = now, 0, 0
+}
+
+func (f *flowControl) status() FlowStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status := FlowStatus{
+		Paused:       f.pausedLocked(),
+		ManualPause:  f.manual,
+		Breaker:      f.breaker,
+		BreakerCause: f.cause,
+		MaxRate:      f.cfg.MaxRate,
+		Burst:        max(f.cfg.Burst, 1),
+	}
+	if !f.pausedAt.IsZero() {
+		pausedAt := f.pausedAt
+		status.PausedAt = &pausedAt
+	}
+	if f.breaker == BreakerOpen {
+		retryAt := f.retryAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
+
+// watch раз в секунду проверяет пул БД и долю ошибок и переключает выключатель
+func (f *flowControl) watch(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(flowCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			f.check(ctx, now, logger)
+		}
+	}
+}
+
+func (f *flowControl) check(ctx context.Context, now time.Time, logger *slog.Logger) {
+	var usage float64
+	if f.cfg.PoolSaturation > 0 && f.cfg.PoolUsage != nil {
+		usage = f.cfg.PoolUsage()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.breaker == BreakerOpen {
+		if now.Before(f.retryAt) {
+			return
+		}
+		f.breaker = BreakerHalfOpen
+		f.resetWindowLocked(now)
+		f.notifyLocked()
+		logger.InfoContext(ctx, "consumer circuit breaker half-open", "cause", f.cause)
+		return
+	}
+
+	cause, detail := f.tripCauseLocked(usage)
+	if cause != "" {
+		f.breaker, f.cause, f.retryAt = BreakerOpen, cause, now.Add(f.cfg.Cooldown)
+		f.resetWindowLocked(now)
+		f.notifyLocked()
+		metrics.BreakerTrips.WithLabelValues(cause).Inc()
+		logger.WarnContext(ctx, "consumer circuit breaker open, ingestion paused", "cause", cause, "detail", detail, "retry_at", f.retryAt)
+		return
+	}
+
+	if now.Sub(f.windowStart) < f.cfg.ErrorWindow {
+		return
+	}
+	if f.breaker == BreakerHalfOpen {
+		f.breaker, f.cause = BreakerClosed, ""
+		f.notifyLocked()
+		logger.InfoContext(ctx, "consumer circuit breaker closed")
+	}
+	f.resetWindowLocked(now)
+}
+
+func (f *flowControl) tripCauseLocked(usage float64) (string, string) {
+	if f.cfg.PoolSaturation > 0 && usage >= f.cfg.PoolSaturation {
+		return CausePoolSaturated, fmt.Sprintf("pool usage %.2f", usage)
+	}
+	if f.cfg.ErrorRate > 0 && f.total > 0 && f.total >= f.cfg.MinMessages {
+		if rate := float64(f.fails) / float64(f.total); rate >= f.cfg.ErrorRate {
+			return CauseErrorRate, fmt.Sprintf("%d of %d messages failed", f.fails, f.total)
+		}
+	}
+	return "", ""
+}
+
+// tokenBucket пропускает не больше rate сообщений в секунду с запасом burst; rate <= 0 - без ограничения
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	b := &tokenBucket{}
+	b.set(rate, burst)
+	return b
+}
+
+func (b *tokenBucket) set(rate float64, burst int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if burst < 1 {
+		burst = 1
+	}
+	b.rate, b.burst = rate, float64(burst)
+	b.tokens, b.last = b.burst, time.Now()
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		if b.rate <= 0 {
+			b.mu.Unlock()
+			return nil
+		}
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// statusMessage - сообщение топика статусов: {"order_uid", "status", "reason"}
+type statusMessage struct {
+	OrderUID string `json:"order_uid"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason"`
+}
+
+// cancellationMessage - сообщение топика отмен: {"order_uid", "reason"}
+type cancellationMessage struct {
+	OrderUID string `json:"order_uid"`
+	Reason   string `json:"reason"`
+}
+
+func (c *OrderConsumer) handleStatus(ctx context.Context, msg kafka.Message, tenant string) (string, error) {
+	update, err := decodeStatus(msg, tenant)
+	if err != nil {
+		return metrics.ReasonUnmarshal, err
+	}
+	return c.updateStatus(ctx, update)
+}
+
+func (c *OrderConsumer) handleCancellation(ctx context.Context, msg kafka.Message, tenant string) (string, error) {
+	update, err := decodeCancellation(msg, tenant)
+	if err != nil {
+		return metrics.ReasonUnmarshal, err
+	}
+	return c.updateStatus(ctx, update)
+}
+
+func decodeStatus(msg kafka.Message, tenant string) (domain.StatusUpdate, error) {
+	var m statusMessage
+	if err := json.Unmarshal(msg.Value, &m); err != nil {
+		return domain.StatusUpdate{}, fmt.Errorf("invalid status message: %w", err)
+	}
+	status, err := domain.ParseOrderStatus(m.Status)
+	if err != nil {
+		return domain.StatusUpdate{}, err
+	}
+	return newStatusUpdate(tenant, m.OrderUID, status, m.Reason)
+}
+
+func decodeCancellation(msg kafka.Message, tenant string) (domain.StatusUpdate, error) {
+	var m cancellationMessage
+	if err := json.Unmarshal(msg.Value, &m); err != nil {
+		return domain.StatusUpdate{}, fmt.Errorf("invalid cancellation message: %w", err)
+	}
+	return newStatusUpdate(tenant, m.OrderUID, domain.OrderStatusCancelled, m.Reason)
+}
+
+func newStatusUpdate(tenant, rawUID, status, reason string) (domain.StatusUpdate, error) {
+	orderUID, _, err := domain.ParseOrderUID(rawUID)
+	if err != nil {
+		return domain.StatusUpdate{}, err
+	}
+	return domain.StatusUpdate{Tenant: tenant, OrderUID: orderUID, Status: status, Reason: reason}, nil
+}
+
+func (c *OrderConsumer) updateStatus(ctx context.Context, update domain.StatusUpdate) (string, error) {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("order.uid", update.OrderUID.String()))
+
+	//Смена статуса, обогнавшая заказ из другого топика, отложена в БД: это не сбой сохранения,
+	//выключатель ее не учитывает, а оффсет можно коммитить
+	if err := c.service.UpdateOrderStatusFromKafka(ctx, update); err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return metrics.ReasonOrderNotFound, err
+		}
+		return metrics.ReasonSave, err
+	}
+	return "", nil
+}
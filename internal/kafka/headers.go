@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"L0WB/internal/domain"
+	"fmt"
+)
+
+// Заголовки, которые продюсер ставит на каждое сообщение
+const (
+	HeaderSchemaVersion = "schema_version"
+	HeaderContentType   = "content_type"
+	HeaderProducerID    = "producer_id"
+	// Витрина сообщения для топиков без закрепленной витрины; иначе должна с ней совпадать
+	HeaderTenant = "tenant"
+
+	// Только у событий из outbox
+	HeaderEventID   = "event_id"
+	HeaderEventType = "event_type"
+)
+
+// Поля заказа, которые можно использовать ключом сообщения
+const (
+	KeyOrderUID   = "order_uid"
+	KeyCustomerID = "customer_id"
+	KeyShardKey   = "shard_key"
+)
+
+// keyFunc возвращает ключ сообщения для заказа. Сообщения с одним ключом попадают в одну партицию
+type keyFunc func(order *domain.CompleteFakeOrder) []byte
+
+func parseKeyField(field string) (keyFunc, error) {
+	switch field {
+	case KeyOrderUID, "":
+		return func(order *domain.CompleteFakeOrder) []byte { return []byte(order.OrderUID) }, nil
+	case KeyCustomerID:
+		return func(order *domain.CompleteFakeOrder) []byte { return []byte(order.CustomerID) }, nil
+	case KeyShardKey:
+		return func(order *domain.CompleteFakeOrder) []byte { return []byte(order.ShardKey) }, nil
+	default:
+		return nil, fmt.Errorf("unknown message key field %q", field)
+	}
+}
@@ -0,0 +1,24 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PingBrokers проверяет, что хотя бы один брокер доступен
+func PingBrokers(ctx context.Context, brokers []string, security *Security) error {
+	dialer := security.dialer()
+
+	var errs []error
+	for _, broker := range brokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", broker, err))
+			continue
+		}
+		_ = conn.Close()
+		return nil
+	}
+	return fmt.Errorf("no kafka broker reachable: %w", errors.Join(errs...))
+}
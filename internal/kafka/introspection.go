@@ -0,0 +1,266 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"os"
+	"sync"
+	"time"
+)
+
+// ConsumerStatus - состояние группы консьюмера: участники, назначенные партиции, оффсеты и отставание
+type ConsumerStatus struct {
+	GroupID    string            `json:"group_id"`
+	GroupState string            `json:"group_state,omitempty"`
+	ClientID   string            `json:"client_id"`
+	Topics     []string          `json:"topics"`
+	Members    []GroupMember     `json:"members"`
+	Partitions []PartitionStatus `json:"partitions"`
+	TotalLag   int64             `json:"total_lag"`
+	Reader     ReaderStatus      `json:"reader"`
+	Flow       FlowStatus        `json:"flow"`
+	// Ошибки запросов к брокерам: отчет при этом может быть неполным
+	Errors []string `json:"errors,omitempty"`
+}
+
+type GroupMember struct {
+	MemberID   string           `json:"member_id"`
+	ClientID   string           `json:"client_id"`
+	ClientHost string           `json:"client_host"`
+	Local      bool             `json:"local"`
+	Partitions map[string][]int `json:"partitions"`
+}
+
+// PartitionStatus - партиция топика группы. Lag считается от закоммиченного оффсета,
+// без коммита - от начала партиции
+type PartitionStatus struct {
+	Topic           string           `json:"topic"`
+	Partition       int              `json:"partition"`
+	MemberID        string           `json:"member_id,omitempty"`
+	Local           bool             `json:"local"`
+	CommittedOffset int64            `json:"committed_offset"`
+	HighWatermark   int64            `json:"high_watermark"`
+	Lag             int64            `json:"lag"`
+	LastOffset      *int64           `json:"last_offset,omitempty"`
+	LastProcessedAt *time.Time       `json:"last_processed_at,omitempty"`
+	Processed       int64            `json:"processed"`
+	Errors          map[string]int64 `json:"errors,omitempty"`
+}
+
+// ReaderStatus - счетчики kafka.Reader с момента старта консьюмера
+type ReaderStatus struct {
+	Messages      int64 `json:"messages"`
+	Bytes         int64 `json:"bytes"`
+	Fetches       int64 `json:"fetches"`
+	Dials         int64 `json:"dials"`
+	Rebalances    int64 `json:"rebalances"`
+	Timeouts      int64 `json:"timeouts"`
+	Errors        int64 `json:"errors"`
+	ReadErrors    int64 `json:"read_errors"`
+	QueueLength   int64 `json:"queue_length"`
+	QueueCapacity int64 `json:"queue_capacity"`
+}
+
+type partitionKey struct {
+	topic     string
+	partition int
+}
+
+type partitionProgress struct {
+	lastOffset  int64
+	processedAt time.Time
+	processed   int64
+	errors      map[string]int64
+}
+
+// progressTracker копит обработку по партициям на этом экземпляре и счетчики reader.
+// kafka.Reader.Stats() обнуляет счетчики при каждом вызове, поэтому они суммируются здесь
+type progressTracker struct {
+	mu         sync.Mutex
+	partitions map[partitionKey]*partitionProgress
+	reader     ReaderStatus
+}
+
+func newProgressTracker() *progressTracker {
+	return &progressTracker{partitions: map[partitionKey]*partitionProgress{}}
+}
+
+func (t *progressTracker) record(msg kafka.Message, reason string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := partitionKey{topic: msg.Topic, partition: msg.Partition}
+	p, ok := t.partitions[key]
+	if !ok {
+		p = &partitionProgress{errors: map[string]int64{}}
+		t.partitions[key] = p
+	}
+	p.lastOffset = msg.Offset
+	p.processedAt = time.Now()
+	p.processed++
+	if reason != "" {
+		p.errors[reason]++
+	}
+}
+
+func (t *progressTracker) readError() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.reader.ReadErrors++
+}
+
+func (t *progressTracker) addStats(stats kafka.ReaderStats) ReaderStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.reader.Messages += stats.Messages
+	t.reader.Bytes += stats.Bytes
+	t.reader.Fetches += stats.Fetches
+	t.reader.Dials += stats.Dials
+	t.reader.Rebalances += stats.Rebalances
+	t.reader.Timeouts += stats.Timeouts
+	t.reader.Errors += stats.Errors
+	t.reader.QueueLength = stats.QueueLength
+	t.reader.QueueCapacity = stats.QueueCapacity
+	return t.reader
+}
+
+func (t *progressTracker) fill(status *PartitionStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[partitionKey{topic: status.Topic, partition: status.Partition}]
+	if !ok {
+		return
+	}
+	lastOffset, processedAt := p.lastOffset, p.processedAt
+	status.LastOffset = &lastOffset
+	status.LastProcessedAt = &processedAt
+	status.Processed = p.processed
+	if len(p.errors) > 0 {
+		status.Errors = make(map[string]int64, len(p.errors))
+		for reason, count := range p.errors {
+			status.Errors[reason] = count
+		}
+	}
+}
+
+// defaultClientID отличает экземпляры в группе: по нему в описании группы находится свой участник
+func defaultClientID() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("order-service-%s-%d", host, os.Getpid())
+}
+
+// Status собирает состояние группы из kafka.Reader.Stats() и метаданных брокеров:
+// участники и их партиции (DescribeGroups), закоммиченные оффсеты (OffsetFetch)
+// и high watermark партиций (ListOffsets)
+func (c *OrderConsumer) Status(ctx context.Context) ConsumerStatus {
+	status := ConsumerStatus{
+		GroupID:  c.groupID,
+		ClientID: c.clientID,
+		Topics:   c.topics,
+		Members:  []GroupMember{},
+		Reader:   c.progress.addStats(c.reader.Stats()),
+		Flow:     c.flow.status(),
+	}
+	owners := map[partitionKey]string{}
+
+	groups, err := c.client.DescribeGroups(ctx, &kafka.DescribeGroupsRequest{GroupIDs: []string{c.groupID}})
+	if err != nil {
+		status.Errors = append(status.Errors, fmt.Sprintf("describe group: %v", err))
+	} else {
+		for _, group := range groups.Groups {
+			if group.Error != nil {
+				status.Errors = append(status.Errors, fmt.Sprintf("describe group: %v", group.Error))
+				continue
+			}
+			status.GroupState = group.GroupState
+			for _, m := range group.Members {
+				member := GroupMember{
+					MemberID:   m.MemberID,
+					ClientID:   m.ClientID,
+					ClientHost: m.ClientHost,
+					Local:      m.ClientID == c.clientID,
+					Partitions: map[string][]int{},
+				}
+				for _, topic := range m.MemberAssignments.Topics {
+					member.Partitions[topic.Topic] = topic.Partitions
+					for _, partition := range topic.Partitions {
+						owners[partitionKey{topic: topic.Topic, partition: partition}] = m.MemberID
+					}
+				}
+				status.Members = append(status.Members, member)
+			}
+		}
+	}
+
+	partitions, err := topicPartitions(ctx, c.client, c.topics)
+	if err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	}
+
+	committed := map[partitionKey]int64{}
+	offsets, err := c.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{GroupID: c.groupID, Topics: partitions})
+	if err == nil {
+		err = offsets.Error
+	}
+	if err != nil {
+		status.Errors = append(status.Errors, fmt.Sprintf("fetch committed offsets: %v", err))
+	} else {
+		for topic, list := range offsets.Topics {
+			for _, p := range list {
+				committed[partitionKey{topic: topic, partition: p.Partition}] = p.CommittedOffset
+			}
+		}
+	}
+
+	watermarks := map[partitionKey]int64{}
+	requests := make(map[string][]kafka.OffsetRequest, len(partitions))
+	for topic, list := range partitions {
+		for _, partition := range list {
+			requests[topic] = append(requests[topic], kafka.LastOffsetOf(partition))
+		}
+	}
+	listed, err := c.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: requests})
+	if err != nil {
+		status.Errors = append(status.Errors, fmt.Sprintf("list offsets: %v", err))
+	} else {
+		for topic, list := range listed.Topics {
+			for _, p := range list {
+				if p.Error != nil {
+					status.Errors = append(status.Errors, fmt.Sprintf("list offsets %s/%d: %v", topic, p.Partition, p.Error))
+					continue
+				}
+				watermarks[partitionKey{topic: topic, partition: p.Partition}] = p.LastOffset
+			}
+		}
+	}
+
+	for _, topic := range c.topics {
+		for _, partition := range partitions[topic] {
+			key := partitionKey{topic: topic, partition: partition}
+			ps := PartitionStatus{
+				Topic:           topic,
+				Partition:       partition,
+				MemberID:        owners[key],
+				CommittedOffset: -1,
+				HighWatermark:   watermarks[key],
+			}
+			if offset, ok := committed[key]; ok {
+				ps.CommittedOffset = offset
+			}
+			ps.Lag = ps.HighWatermark - max(ps.CommittedOffset, 0)
+			for _, member := range status.Members {
+				if member.MemberID == ps.MemberID {
+					ps.Local = member.Local
+				}
+			}
+			c.progress.fill(&ps)
+
+			status.TotalLag += ps.Lag
+			status.Partitions = append(status.Partitions, ps)
+		}
+	}
+	return status
+}
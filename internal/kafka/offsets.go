@@ -0,0 +1,296 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Виды позиции в партиции
+const (
+	OffsetEarliest  = "earliest"
+	OffsetLatest    = "latest"
+	OffsetTimestamp = "timestamp"
+	OffsetExact     = "offset"
+)
+
+// Состояния группы, в которых брокер принимает оффсеты не от участника группы
+var inactiveGroupStates = map[string]bool{"Empty": true, "Dead": true}
+
+// OffsetSpec - позиция в партиции: earliest, latest, время RFC3339 или номер оффсета
+type OffsetSpec struct {
+	Kind   string
+	Time   time.Time
+	Offset int64
+}
+
+func ParseOffsetSpec(s string) (OffsetSpec, error) {
+	switch s = strings.TrimSpace(s); s {
+	case OffsetEarliest:
+		return OffsetSpec{Kind: OffsetEarliest}, nil
+	case OffsetLatest:
+		return OffsetSpec{Kind: OffsetLatest}, nil
+	}
+	if offset, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if offset < 0 {
+			return OffsetSpec{}, fmt.Errorf("negative offset %d", offset)
+		}
+		return OffsetSpec{Kind: OffsetExact, Offset: offset}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return OffsetSpec{Kind: OffsetTimestamp, Time: t}, nil
+	}
+	return OffsetSpec{}, fmt.Errorf("invalid offset %q: expected earliest, latest, offset or RFC3339 time", s)
+}
+
+func (s OffsetSpec) String() string {
+	switch s.Kind {
+	case OffsetTimestamp:
+		return s.Time.Format(time.RFC3339)
+	case OffsetExact:
+		return strconv.FormatInt(s.Offset, 10)
+	}
+	return s.Kind
+}
+
+// ParsePartitionOffsets разбирает явные оффсеты партиций "0=100,1=250"
+func ParsePartitionOffsets(s string) (map[int]int64, error) {
+	offsets := map[int]int64{}
+	for _, pair := range strings.Split(s, ",") {
+		partition, offset, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid partition offset %q: expected <partition>=<offset>", pair)
+		}
+		p, err := strconv.Atoi(partition)
+		if err != nil || p < 0 {
+			return nil, fmt.Errorf("invalid partition %q", partition)
+		}
+		o, err := strconv.ParseInt(offset, 10, 64)
+		if err != nil || o < 0 {
+			return nil, fmt.Errorf("invalid offset %q for partition %d", offset, p)
+		}
+		offsets[p] = o
+	}
+	return offsets, nil
+}
+
+// OffsetReset - сброс оффсетов группы в топике. Явные оффсеты Partitions сбрасывают только
+// перечисленные партиции, иначе все партиции топика переводятся в позицию To
+type OffsetReset struct {
+	Brokers    []string
+	GroupID    string
+	Topic      string
+	To         OffsetSpec
+	Partitions map[int]int64
+	DryRun     bool
+	Security   SecurityConfig
+}
+
+// OffsetChange - оффсет партиции до и после сброса; -1 - оффсет не закоммичен
+type OffsetChange struct {
+	Topic     string
+	Partition int
+	From      int64
+	To        int64
+}
+
+// ResetOffsets коммитит новые оффсеты группы. Брокер принимает их только от неактивной
+// группы, поэтому консьюмеры группы должны быть остановлены; с DryRun только считает план
+func ResetOffsets(ctx context.Context, req OffsetReset) ([]OffsetChange, error) {
+	security, err := NewSecurity(req.Security)
+	if err != nil {
+		return nil, err
+	}
+	client := newClient(req.Brokers, security)
+
+	groups, err := client.DescribeGroups(ctx, &kafka.DescribeGroupsRequest{GroupIDs: []string{req.GroupID}})
+	if err != nil {
+		return nil, fmt.Errorf("error describing group %s: %v", req.GroupID, err)
+	}
+	for _, group := range groups.Groups {
+		if group.Error != nil {
+			return nil, fmt.Errorf("error describing group %s: %v", req.GroupID, group.Error)
+		}
+		if !inactiveGroupStates[group.GroupState] {
+			return nil, fmt.Errorf("group %s is %s with %d members: stop its consumers before resetting offsets",
+				req.GroupID, group.GroupState, len(group.Members))
+		}
+	}
+
+	all, err := topicPartitions(ctx, client, []string{req.Topic})
+	if err != nil {
+		return nil, err
+	}
+	partitions := all[req.Topic]
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", req.Topic)
+	}
+
+	var target map[int]int64
+	if len(req.Partitions) > 0 {
+		known := map[int]bool{}
+		for _, p := range partitions {
+			known[p] = true
+		}
+		partitions = partitions[:0]
+		for p := range req.Partitions {
+			if !known[p] {
+				return nil, fmt.Errorf("topic %s has no partition %d", req.Topic, p)
+			}
+			partitions = append(partitions, p)
+		}
+		sort.Ints(partitions)
+		target = req.Partitions
+	} else if target, err = resolveOffsets(ctx, client, req.Topic, partitions, req.To); err != nil {
+		return nil, err
+	}
+
+	current, err := client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: req.GroupID,
+		Topics:  map[string][]int{req.Topic: partitions},
+	})
+	if err == nil {
+		err = current.Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching committed offsets: %v", err)
+	}
+	committed := map[int]int64{}
+	for _, p := range current.Topics[req.Topic] {
+		committed[p.Partition] = p.CommittedOffset
+	}
+
+	changes := make([]OffsetChange, 0, len(partitions))
+	commits := make([]kafka.OffsetCommit, 0, len(partitions))
+	for _, p := range partitions {
+		from, ok := committed[p]
+		if !ok {
+			from = -1
+		}
+		changes = append(changes, OffsetChange{Topic: req.Topic, Partition: p, From: from, To: target[p]})
+		commits = append(commits, kafka.OffsetCommit{Partition: p, Offset: target[p], Metadata: "reset"})
+	}
+	if req.DryRun {
+		return changes, nil
+	}
+
+	res, err := client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      req.GroupID,
+		GenerationID: -1,
+		Topics:       map[string][]kafka.OffsetCommit{req.Topic: commits},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error committing offsets: %v", err)
+	}
+	var errs []error
+	for _, p := range res.Topics[req.Topic] {
+		if p.Error != nil {
+			errs = append(errs, fmt.Errorf("partition %d: %w", p.Partition, p.Error))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("error committing offsets: %w", errors.Join(errs...))
+	}
+	return changes, nil
+}
+
+// newClient - клиент служебных запросов к брокерам (метаданные, оффсеты, группы)
+func newClient(brokers []string, security *Security) *kafka.Client {
+	return &kafka.Client{
+		Addr:      kafka.TCP(brokers...),
+		Transport: security.transport(),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// topicPartitions возвращает номера партиций топиков по метаданным кластера
+func topicPartitions(ctx context.Context, client *kafka.Client, topics []string) (map[string][]int, error) {
+	metadata, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: topics})
+	if err != nil {
+		return nil, fmt.Errorf("read metadata: %v", err)
+	}
+
+	partitions := map[string][]int{}
+	var errs []error
+	for _, topic := range metadata.Topics {
+		if topic.Error != nil {
+			errs = append(errs, fmt.Errorf("read metadata %s: %v", topic.Name, topic.Error))
+			continue
+		}
+		for _, p := range topic.Partitions {
+			partitions[topic.Name] = append(partitions[topic.Name], p.ID)
+		}
+		sort.Ints(partitions[topic.Name])
+	}
+	return partitions, errors.Join(errs...)
+}
+
+// resolveOffsets переводит позицию в оффсеты партиций. Для времени берется первый оффсет
+// с меткой не раньше него, а если таких сообщений нет - конец партиции
+func resolveOffsets(ctx context.Context, client *kafka.Client, topic string, partitions []int, spec OffsetSpec) (map[int]int64, error) {
+	switch spec.Kind {
+	case OffsetEarliest:
+		return listOffsets(ctx, client, topic, partitions, kafka.FirstOffset)
+	case OffsetLatest:
+		return listOffsets(ctx, client, topic, partitions, kafka.LastOffset)
+	case OffsetExact:
+		offsets := make(map[int]int64, len(partitions))
+		for _, p := range partitions {
+			offsets[p] = spec.Offset
+		}
+		return offsets, nil
+	case OffsetTimestamp:
+		offsets, err := listOffsets(ctx, client, topic, partitions, spec.Time.UnixMilli())
+		if err != nil {
+			return nil, err
+		}
+		latest, err := listOffsets(ctx, client, topic, partitions, kafka.LastOffset)
+		if err != nil {
+			return nil, err
+		}
+		for p, offset := range offsets {
+			if offset < 0 {
+				offsets[p] = latest[p]
+			}
+		}
+		return offsets, nil
+	}
+	return nil, fmt.Errorf("unknown offset kind %q", spec.Kind)
+}
+
+// listOffsets запрашивает оффсеты партиций по одной метке времени (или FirstOffset/LastOffset).
+// Брокер возвращает в ответе метку найденного сообщения, а для earliest и latest - -1, поэтому
+// kafka-go кладет их в LastOffset, а найденные по времени - в Offsets
+func listOffsets(ctx context.Context, client *kafka.Client, topic string, partitions []int, timestamp int64) (map[int]int64, error) {
+	requests := make([]kafka.OffsetRequest, len(partitions))
+	for i, p := range partitions {
+		requests[i] = kafka.OffsetRequest{Partition: p, Timestamp: timestamp}
+	}
+	res, err := client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: map[string][]kafka.OffsetRequest{topic: requests}})
+	if err != nil {
+		return nil, fmt.Errorf("error listing offsets of %s: %v", topic, err)
+	}
+
+	offsets := make(map[int]int64, len(partitions))
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("error listing offsets of %s/%d: %v", topic, p.Partition, p.Error)
+		}
+		switch {
+		case len(p.Offsets) > 0:
+			for offset := range p.Offsets {
+				offsets[p.Partition] = offset
+			}
+		case timestamp == kafka.FirstOffset && p.LastOffset < 0:
+			offsets[p.Partition] = p.FirstOffset
+		default:
+			offsets[p.Partition] = p.LastOffset
+		}
+	}
+	return offsets, nil
+}
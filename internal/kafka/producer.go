@@ -2,47 +2,294 @@ package kafka
 
 import (
 	"L0WB/internal/domain"
+	"L0WB/internal/envelope"
+	"L0WB/internal/logger"
+	"L0WB/internal/metrics"
 	"context"
-	"github.com/ogen-go/ogen/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
-	"log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"os"
+	"strconv"
 	"time"
 )
 
+type ProducerConfig struct {
+	Brokers []string
+	Topic   string
+	// Поле заказа для ключа сообщения: order_uid (по умолчанию), customer_id или shard_key
+	KeyField string
+	// Идентификатор продюсера в заголовке producer_id, по умолчанию - имя хоста
+	ProducerID string
+	Security   SecurityConfig
+	// Кодек сообщений: json (по умолчанию), protobuf или avro; версия схемы заказа 1 или 2.
+	// Схемы Avro берутся из встроенного реестра и каталога SchemaRegistryDir
+	Codec             string
+	SchemaVersion     int
+	SchemaRegistryDir string
+
+	// Батч отправляется, когда набралось BatchSize сообщений или прошло BatchTimeout (linger)
+	BatchSize    int
+	BatchTimeout time.Duration
+	// Async: SendOrder не ждет записи, результат приходит в OnDelivery
+	Async bool
+	// RequiredAcks: none, one или all (по умолчанию)
+	RequiredAcks string
+	// Compression: none (по умолчанию), gzip, snappy, lz4 или zstd
+	Compression string
+	// OnDelivery вызывается для каждого сообщения после записи или ошибки, в том числе в синхронном режиме.
+	// Вызывается из горутины writer'а и не должен блокироваться
+	OnDelivery func(DeliveryReport)
+}
+
+// DeliveryReport - результат доставки одного сообщения
+type DeliveryReport struct {
+	OrderUID  string
+	Topic     string
+	Partition int
+	Offset    int64
+	Latency   time.Duration
+	Err       error
+}
+
+// delivery передается через kafka.Message.WriterData в отчет о доставке
+type delivery struct {
+	orderUID string
+	enqueued time.Time
+}
+
 type OrderProducer struct {
-	writer *kafka.Writer
-	topic  string
+	writer     *kafka.Writer
+	topic      string
+	key        keyFunc
+	producerID string
+	codec      envelope.Codec
+	version    int
+	async      bool
+	onDelivery func(DeliveryReport)
+	logger     *slog.Logger
 }
 
-func NewOrderProducer(brokers []string, topic string) *OrderProducer {
-	return &OrderProducer{
-		writer: &kafka.Writer{
-			Addr:     kafka.TCP(brokers...),
-			Topic:    topic,
-			Balancer: &kafka.LeastBytes{},
-		},
-		topic: topic,
+func NewOrderProducer(cfg ProducerConfig, logger *slog.Logger) (*OrderProducer, error) {
+	key, err := parseKeyField(cfg.KeyField)
+	if err != nil {
+		return nil, err
+	}
+	acks, err := parseRequiredAcks(cfg.RequiredAcks)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := parseCompression(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	security, err := NewSecurity(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := envelope.LoadRegistry(cfg.SchemaRegistryDir)
+	if err != nil {
+		return nil, err
+	}
+	codecs, err := envelope.NewCodecs(registry)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := codecs.ByName(cfg.Codec)
+	if err != nil {
+		return nil, err
+	}
+	version := cfg.SchemaVersion
+	if version == 0 {
+		version = envelope.Version1
+	}
+	if _, err := registry.Lookup(envelope.OrderSubject, version); err != nil {
+		return nil, err
+	}
+
+	producerID := cfg.ProducerID
+	if producerID == "" {
+		producerID, _ = os.Hostname()
+	}
+
+	p := &OrderProducer{
+		topic:      cfg.Topic,
+		key:        key,
+		producerID: producerID,
+		codec:      codec,
+		version:    version,
+		async:      cfg.Async,
+		onDelivery: cfg.OnDelivery,
+		logger:     logger.With("component", "producer", "topic", cfg.Topic),
+	}
+	p.writer = &kafka.Writer{
+		Addr:      kafka.TCP(cfg.Brokers...),
+		Topic:     cfg.Topic,
+		Transport: security.transport(),
+		//Тот же хеш ключа, что у Java-клиента: все события одного заказа попадают в одну партицию
+		Balancer:     &kafka.Murmur2Balancer{},
+		BatchSize:    cfg.BatchSize,
+		BatchTimeout: cfg.BatchTimeout,
+		RequiredAcks: acks,
+		Compression:  compression,
+		Async:        cfg.Async,
+		Completion:   p.completion,
+	}
+	return p, nil
+}
+
+func parseRequiredAcks(s string) (kafka.RequiredAcks, error) {
+	switch s {
+	case "all", "":
+		return kafka.RequireAll, nil
+	case "one":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	default:
+		return 0, fmt.Errorf("unknown required acks %q", s)
+	}
+}
+
+func parseCompression(s string) (kafka.Compression, error) {
+	switch s {
+	case "none", "":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	default:
+		return 0, fmt.Errorf("unknown compression %q", s)
 	}
 }
 
 func (p *OrderProducer) SendOrder(ctx context.Context, order *domain.CompleteFakeOrder) error {
-	jsonData, err := json.Marshal(order)
-	if err != nil {
-		return err
+	return p.SendOrders(ctx, []*domain.CompleteFakeOrder{order})
+}
+
+// SendOrders отправляет заказы одним вызовом writer'а: они уходят батчами по партициям.
+// В синхронном режиме возвращает ошибку, если не доставлено хотя бы одно сообщение
+func (p *OrderProducer) SendOrders(ctx context.Context, orders []*domain.CompleteFakeOrder) error {
+	ctx, span := tracer.Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(p.topic),
+			semconv.MessagingBatchMessageCount(len(orders)),
+		),
+	)
+	defer span.End()
+	if len(orders) == 1 {
+		span.SetAttributes(attribute.String("order.uid", orders[0].OrderUID))
 	}
 
-	err = p.writer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(time.Now().Format(time.RFC3339)),
-		Value: jsonData,
-	})
-	if err != nil {
+	correlationID := logger.CorrelationID(ctx)
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+		ctx = logger.WithCorrelationID(ctx, correlationID)
+	}
+
+	msgs := make([]kafka.Message, 0, len(orders))
+	for _, order := range orders {
+		msg, err := p.message(ctx, order, correlationID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	if err := p.writer.WriteMessages(ctx, msgs...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		var writeErrs kafka.WriteErrors
+		if errors.As(err, &writeErrs) {
+			return fmt.Errorf("%d of %d messages not delivered: %w", writeErrs.Count(), len(msgs), err)
+		}
 		return err
 	}
 
-	log.Printf("Producer sent order to kafka: %s", string(jsonData))
+	if p.async {
+		p.logger.DebugContext(ctx, "orders enqueued", "count", len(orders))
+	} else {
+		p.logger.InfoContext(ctx, "orders sent to kafka", "count", len(orders))
+	}
 	return nil
 }
 
+func (p *OrderProducer) message(ctx context.Context, order *domain.CompleteFakeOrder, correlationID string) (kafka.Message, error) {
+	env, err := envelope.NewOrderEnvelope(order, p.version, time.Now())
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	data, err := p.codec.Encode(env)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	msg := kafka.Message{
+		Key:   p.key(order),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(p.version))},
+			{Key: HeaderContentType, Value: []byte(p.codec.ContentType())},
+			{Key: HeaderProducerID, Value: []byte(p.producerID)},
+		},
+		WriterData: delivery{orderUID: order.OrderUID, enqueued: time.Now()},
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{msg: &msg})
+	headerCarrier{msg: &msg}.Set(correlationIDHeader, correlationID)
+	return msg, nil
+}
+
+// completion вызывается writer'ом для каждого записанного (или не записанного) батча
+func (p *OrderProducer) completion(messages []kafka.Message, err error) {
+	metrics.ProducerBatchSize.WithLabelValues(p.topic).Observe(float64(len(messages)))
+
+	result := "delivered"
+	if err != nil {
+		result = "failed"
+		//В синхронном режиме ошибку получает вызывающий код
+		if p.async {
+			p.logger.Error("kafka delivery failed", "count", len(messages), "error", err)
+		}
+	}
+	metrics.ProducerMessages.WithLabelValues(p.topic, result).Add(float64(len(messages)))
+
+	for _, msg := range messages {
+		d, _ := msg.WriterData.(delivery)
+		latency := time.Since(d.enqueued)
+		metrics.ProducerWriteDuration.WithLabelValues(p.topic).Observe(latency.Seconds())
+
+		if p.onDelivery != nil {
+			p.onDelivery(DeliveryReport{
+				OrderUID:  d.orderUID,
+				Topic:     msg.Topic,
+				Partition: msg.Partition,
+				Offset:    msg.Offset,
+				Latency:   latency,
+				Err:       err,
+			})
+		}
+	}
+}
+
+// Close дожидается отправки накопленных батчей и закрывает writer
 func (p *OrderProducer) Close() error {
 	return p.writer.Close()
 }
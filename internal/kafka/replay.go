@@ -0,0 +1,268 @@
+package kafka
+
+import (
+	"L0WB/internal/metrics"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"time"
+)
+
+// Режимы повторного чтения: dry-run только читает диапазон, validate разбирает и проверяет
+// сообщения, apply передает их обработчикам топика как живой консьюмер
+const (
+	ReplayDryRun   = "dry-run"
+	ReplayValidate = "validate"
+	ReplayApply    = "apply"
+)
+
+// Лимит сообщений за запуск: больший диапазон читается несколькими запусками с NextOffset
+const (
+	DefaultReplayLimit = 10000
+	MaxReplayLimit     = 100000
+)
+
+// ErrInvalidReplay - запрос нельзя выполнить: неверные режим, лимит, топик или партиции
+var ErrInvalidReplay = errors.New("invalid replay request")
+
+const (
+	maxReplayErrors = 100
+	// Конец диапазона может не достигаться сообщением (маркеры транзакций, compaction)
+	replayIdleTimeout = 5 * time.Second
+)
+
+// ReplayRequest - диапазон топика [From, To) по партициям; без Partitions - все партиции,
+// без Topic - топик консьюмера, если он читает один топик
+type ReplayRequest struct {
+	Topic      string
+	Partitions []int
+	From       OffsetSpec
+	To         OffsetSpec
+	Mode       string
+	// Максимум сообщений за запуск, от 1 до MaxReplayLimit
+	Limit int
+}
+
+type ReplayReport struct {
+	Topic      string            `json:"topic"`
+	Handler    string            `json:"handler"`
+	Mode       string            `json:"mode"`
+	Partitions []ReplayPartition `json:"partitions"`
+	Read       int               `json:"read"`
+	Valid      int               `json:"valid"`
+	Invalid    int               `json:"invalid"`
+	Applied    int               `json:"applied"`
+	Failed     int               `json:"failed"`
+	// Чтение остановлено по лимиту; продолжить можно с NextOffset партиций
+	Truncated bool          `json:"truncated"`
+	Errors    []ReplayError `json:"errors,omitempty"`
+}
+
+type ReplayPartition struct {
+	Partition  int   `json:"partition"`
+	From       int64 `json:"from"`
+	To         int64 `json:"to"`
+	Read       int   `json:"read"`
+	NextOffset int64 `json:"next_offset"`
+}
+
+type ReplayError struct {
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	Reason    string `json:"reason"`
+	Error     string `json:"error"`
+}
+
+func (r *ReplayReport) addError(msg kafka.Message, reason string, err error) {
+	if len(r.Errors) < maxReplayErrors {
+		r.Errors = append(r.Errors, ReplayError{Partition: msg.Partition, Offset: msg.Offset, Reason: reason, Error: err.Error()})
+	}
+}
+
+// Replay читает диапазон топика отдельными reader'ами без группы: оффсеты и участники
+// живой группы не меняются. Границы диапазона приводятся к имеющимся в партиции оффсетам
+func (c *OrderConsumer) Replay(ctx context.Context, req ReplayRequest) (ReplayReport, error) {
+	switch req.Mode {
+	case ReplayDryRun, ReplayValidate, ReplayApply:
+	default:
+		return ReplayReport{}, fmt.Errorf("%w: unknown mode %q", ErrInvalidReplay, req.Mode)
+	}
+	if req.Limit < 1 || req.Limit > MaxReplayLimit {
+		return ReplayReport{}, fmt.Errorf("%w: limit must be from 1 to %d", ErrInvalidReplay, MaxReplayLimit)
+	}
+	if req.Topic == "" {
+		if len(c.topics) != 1 {
+			return ReplayReport{}, fmt.Errorf("%w: topic is required, consumer reads %d topics", ErrInvalidReplay, len(c.topics))
+		}
+		req.Topic = c.topics[0]
+	}
+
+	partitions, err := c.replayPartitions(ctx, req.Topic, req.Partitions)
+	if err != nil {
+		return ReplayReport{}, err
+	}
+	earliest, err := resolveOffsets(ctx, c.client, req.Topic, partitions, OffsetSpec{Kind: OffsetEarliest})
+	if err != nil {
+		return ReplayReport{}, err
+	}
+	latest, err := resolveOffsets(ctx, c.client, req.Topic, partitions, OffsetSpec{Kind: OffsetLatest})
+	if err != nil {
+		return ReplayReport{}, err
+	}
+	from, err := resolveOffsets(ctx, c.client, req.Topic, partitions, req.From)
+	if err != nil {
+		return ReplayReport{}, err
+	}
+	to, err := resolveOffsets(ctx, c.client, req.Topic, partitions, req.To)
+	if err != nil {
+		return ReplayReport{}, err
+	}
+
+	report := ReplayReport{
+		Topic:      req.Topic,
+		Handler:    handlerFor(c.routes, req.Topic),
+		Mode:       req.Mode,
+		Partitions: []ReplayPartition{},
+	}
+	c.logger.InfoContext(ctx, "replay started", "topic", req.Topic, "mode", req.Mode,
+		"from", req.From.String(), "to", req.To.String(), "partitions", partitions)
+
+	for _, p := range partitions {
+		rp := ReplayPartition{
+			Partition: p,
+			From:      min(max(from[p], earliest[p]), latest[p]),
+			To:        min(max(to[p], earliest[p]), latest[p]),
+		}
+		rp.NextOffset = rp.From
+		err := c.replayPartition(ctx, req, &rp, &report)
+		report.Partitions = append(report.Partitions, rp)
+		if err != nil {
+			c.logger.ErrorContext(ctx, "replay failed", "topic", req.Topic, "partition", p, "read", report.Read, "error", err)
+			return report, err
+		}
+		if report.Truncated {
+			break
+		}
+	}
+
+	c.logger.InfoContext(ctx, "replay finished", "topic", req.Topic, "mode", req.Mode, "read", report.Read,
+		"invalid", report.Invalid, "applied", report.Applied, "failed", report.Failed, "truncated", report.Truncated)
+	return report, nil
+}
+
+func (c *OrderConsumer) replayPartitions(ctx context.Context, topic string, requested []int) ([]int, error) {
+	all, err := topicPartitions(ctx, c.client, []string{topic})
+	if err != nil {
+		return nil, err
+	}
+	partitions := all[topic]
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("%w: topic %s has no partitions", ErrInvalidReplay, topic)
+	}
+	if len(requested) == 0 {
+		return partitions, nil
+	}
+
+	known := map[int]bool{}
+	for _, p := range partitions {
+		known[p] = true
+	}
+	for _, p := range requested {
+		if !known[p] {
+			return nil, fmt.Errorf("%w: topic %s has no partition %d", ErrInvalidReplay, topic, p)
+		}
+	}
+	return requested, nil
+}
+
+func (c *OrderConsumer) replayPartition(ctx context.Context, req ReplayRequest, rp *ReplayPartition, report *ReplayReport) error {
+	if rp.From >= rp.To {
+		return nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   c.brokers,
+		Topic:     req.Topic,
+		Partition: rp.Partition,
+		Dialer:    c.security.dialer(),
+		MinBytes:  1,
+		MaxBytes:  10e6,
+		MaxWait:   500 * time.Millisecond,
+	})
+	defer reader.Close()
+
+	if err := reader.SetOffset(rp.From); err != nil {
+		return fmt.Errorf("error seeking %s/%d to %d: %v", req.Topic, rp.Partition, rp.From, err)
+	}
+
+	for rp.NextOffset < rp.To {
+		if report.Read >= req.Limit {
+			report.Truncated = true
+			return nil
+		}
+
+		readCtx, cancel := context.WithTimeout(ctx, replayIdleTimeout)
+		msg, err := reader.ReadMessage(readCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil
+			}
+			return fmt.Errorf("error reading %s/%d: %v", req.Topic, rp.Partition, err)
+		}
+		if msg.Offset >= rp.To {
+			return nil
+		}
+
+		rp.NextOffset = msg.Offset + 1
+		rp.Read++
+		report.Read++
+		c.replayMessage(ctx, msg, req.Mode, report)
+	}
+	return nil
+}
+
+func (c *OrderConsumer) replayMessage(ctx context.Context, msg kafka.Message, mode string, report *ReplayReport) {
+	switch mode {
+	case ReplayValidate:
+		if reason, err := c.validate(ctx, msg, report.Handler); err != nil {
+			report.Invalid++
+			report.addError(msg, reason, err)
+			return
+		}
+		report.Valid++
+
+	case ReplayApply:
+		if reason, err := c.handle(ctx, msg, report.Handler); err != nil {
+			report.Failed++
+			report.addError(msg, reason, err)
+			return
+		}
+		report.Applied++
+	}
+}
+
+// validate разбирает сообщение как обработчик топика, но ничего не сохраняет
+func (c *OrderConsumer) validate(ctx context.Context, msg kafka.Message, handlerName string) (string, error) {
+	tenant, err := c.tenants.resolve(msg.Topic, headerCarrier{msg: &msg}.Get(HeaderTenant))
+	if err != nil {
+		return metrics.ReasonUnmarshal, err
+	}
+
+	switch handlerName {
+	case HandlerStatus:
+		_, err = decodeStatus(msg, tenant)
+	case HandlerCancellation:
+		_, err = decodeCancellation(msg, tenant)
+	default:
+		_, err = c.decode(ctx, msg)
+	}
+	if err != nil {
+		return metrics.ReasonUnmarshal, err
+	}
+	return "", nil
+}
@@ -0,0 +1,117 @@
+package kafka
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/envelope"
+	"L0WB/internal/migrate"
+	"L0WB/internal/repository/order"
+	"L0WB/internal/repository/reconciliation"
+	"L0WB/internal/service"
+	"L0WB/internal/storage"
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/kafka-go"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+// orderMessage - заказ model.json с order_uid в JSON-конверте версии 1
+func orderMessage(t *testing.T, codecs *envelope.Codecs, orderUID, itemName string) kafka.Message {
+	t.Helper()
+	data, err := os.ReadFile("../envelope/testdata/model.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake, _, err := envelope.DecodeOrderV1(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.OrderUID = orderUID
+	fake.Items[0].Name = itemName
+
+	env, err := envelope.NewOrderEnvelope(fake, envelope.Version1, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := codecs.ByName(envelope.CodecJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := codec.Encode(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kafka.Message{
+		Topic:   "orders",
+		Value:   value,
+		Headers: []kafka.Header{{Key: HeaderContentType, Value: []byte(codec.ContentType())}},
+	}
+}
+
+// Повторное чтение в режиме apply заменяет уже сохраненный заказ, а не падает на ключе (tenant, order_uid).
+// Нужна база: TEST_PG_DSN, миграции применяются тестом
+func TestReplayApplyStoredOrder(t *testing.T) {
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	if err := migrate.Run(ctx, dsn, "up", nil, logger); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	repo := order.NewRepository(pool)
+	reconciler := service.NewReconciliationService(reconciliation.NewRepository(pool), logger)
+	svc := service.NewService(repo, storage.NewOrderCache(time.Hour), storage.NewCustomerSummaryCache(time.Minute), reconciler, nil, nil, logger)
+	registry, err := envelope.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	codecs, err := envelope.NewCodecs(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &OrderConsumer{service: svc, codecs: codecs, logger: logger}
+	c.handlers = map[string]messageHandler{HandlerOrders: c.handleOrder}
+
+	id := uuid.New()
+	report := ReplayReport{Handler: HandlerOrders}
+	c.replayMessage(ctx, orderMessage(t, codecs, id.String(), "Mascaras"), ReplayApply, &report)
+	c.replayMessage(ctx, orderMessage(t, codecs, id.String(), "Mascaras fixed"), ReplayApply, &report)
+	if report.Applied != 2 || report.Failed != 0 {
+		t.Fatalf("applied = %d, failed = %d, errors = %+v, want 2 applied", report.Applied, report.Failed, report.Errors)
+	}
+
+	key := domain.OrderKey{Tenant: domain.DefaultTenant, OrderUID: id}
+	stored, err := repo.GetOrder(ctx, key)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if len(stored.Items) != 1 || stored.Items[0].Name != "Mascaras fixed" {
+		t.Errorf("items = %+v, want the replayed item only", stored.Items)
+	}
+
+	var created, updated int
+	err = pool.QueryRow(ctx,
+		`SELECT count(*) FILTER (WHERE event_type = $3), count(*) FILTER (WHERE event_type = $4)
+		 FROM outbox WHERE tenant = $1 AND order_uid = $2`,
+		key.Tenant, key.OrderUID, domain.OrderCreated, domain.OrderUpdated,
+	).Scan(&created, &updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 1 || updated != 1 {
+		t.Errorf("outbox events: created = %d, updated = %d, want 1 and 1", created, updated)
+	}
+}
@@ -0,0 +1,163 @@
+package kafka
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Обработчики топиков
+const (
+	HandlerOrders       = "orders"
+	HandlerStatus       = "status"
+	HandlerCancellation = "cancellation"
+)
+
+// route направляет топики, подходящие под pattern, в обработчик handler
+type route struct {
+	pattern *regexp.Regexp
+	handler string
+}
+
+// parseRoutes разбирает правила вида "<regexp>=<handler>"
+func parseRoutes(rules []string) ([]route, error) {
+	routes := make([]route, 0, len(rules))
+	for _, rule := range rules {
+		i := strings.LastIndex(rule, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid topic route %q, expected <regexp>=<handler>", rule)
+		}
+		pattern, handler := strings.TrimSpace(rule[:i]), strings.TrimSpace(rule[i+1:])
+		switch handler {
+		case HandlerOrders, HandlerStatus, HandlerCancellation:
+		default:
+			return nil, fmt.Errorf("unknown handler %q in topic route %q", handler, rule)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic route %q: %v", rule, err)
+		}
+		routes = append(routes, route{pattern: re, handler: handler})
+	}
+	return routes, nil
+}
+
+// handlerFor возвращает обработчик топика: первое совпавшее правило, иначе заказы
+func handlerFor(routes []route, topic string) string {
+	for _, r := range routes {
+		if r.pattern.MatchString(topic) {
+			return r.handler
+		}
+	}
+	return HandlerOrders
+}
+
+// tenantResolver определяет витрину сообщения: явное соответствие топика, затем группа (?P<tenant>...)
+// в шаблоне топиков. Заголовок tenant задает витрину только топикам без соответствия, иначе он должен
+// с ним совпадать: продюсер топика одной витрины не может записать заказ в другую
+type tenantResolver struct {
+	topicTenants map[string]string
+	pattern      *regexp.Regexp
+}
+
+func (r tenantResolver) resolve(topic, header string) (string, error) {
+	mapped, ok := r.topicTenant(topic)
+	if !ok {
+		if header != "" {
+			return domain.ParseTenant(header)
+		}
+		return domain.DefaultTenant, nil
+	}
+
+	tenant, err := domain.ParseTenant(mapped)
+	if err != nil {
+		return "", err
+	}
+	if header == "" {
+		return tenant, nil
+	}
+	if fromHeader, err := domain.ParseTenant(header); err != nil || fromHeader != tenant {
+		return "", fmt.Errorf("%w: header %q conflicts with tenant %q of topic %s", domain.ErrInvalidTenant, header, tenant, topic)
+	}
+	return tenant, nil
+}
+
+// topicTenant - витрина, закрепленная за топиком в KAFKA_TOPIC_TENANTS или шаблоне топиков
+func (r tenantResolver) topicTenant(topic string) (string, bool) {
+	if tenant, ok := r.topicTenants[topic]; ok {
+		return tenant, true
+	}
+	if r.pattern != nil {
+		if i := r.pattern.SubexpIndex("tenant"); i > 0 {
+			if m := r.pattern.FindStringSubmatch(topic); m != nil {
+				return m[i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// resolveTopics объединяет явный список топиков и топики кластера, подходящие под pattern
+func resolveTopics(ctx context.Context, brokers []string, topics []string, pattern *regexp.Regexp, security *Security) ([]string, error) {
+	set := map[string]bool{}
+	for _, topic := range topics {
+		if topic != "" {
+			set[topic] = true
+		}
+	}
+
+	if pattern != nil {
+		existing, err := listTopics(ctx, brokers, security)
+		if err != nil {
+			return nil, err
+		}
+		for _, topic := range existing {
+			if pattern.MatchString(topic) {
+				set[topic] = true
+			}
+		}
+	}
+
+	resolved := make([]string, 0, len(set))
+	for topic := range set {
+		resolved = append(resolved, topic)
+	}
+	sort.Strings(resolved)
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("no topics to consume")
+	}
+	return resolved, nil
+}
+
+func listTopics(ctx context.Context, brokers []string, security *Security) ([]string, error) {
+	dialer := security.dialer()
+
+	var errs []error
+	for _, broker := range brokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", broker, err))
+			continue
+		}
+		partitions, err := conn.ReadPartitions()
+		_ = conn.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: reading topic metadata: %w", broker, err)
+		}
+
+		seen := map[string]bool{}
+		var topics []string
+		for _, p := range partitions {
+			if !seen[p.Topic] {
+				seen[p.Topic] = true
+				topics = append(topics, p.Topic)
+			}
+		}
+		return topics, nil
+	}
+	return nil, fmt.Errorf("error listing topics: %w", errors.Join(errs...))
+}
@@ -0,0 +1,48 @@
+package kafka
+
+import (
+	"L0WB/internal/domain"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestTenantResolver(t *testing.T) {
+	r := tenantResolver{
+		topicTenants: map[string]string{"orders-eu": "eu"},
+		pattern:      regexp.MustCompile(`^(?P<tenant>[a-z]+)\.orders$`),
+	}
+
+	tests := []struct {
+		name    string
+		topic   string
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{name: "mapped topic", topic: "orders-eu", want: "eu"},
+		{name: "pattern topic", topic: "kz.orders", want: "kz"},
+		{name: "header matches mapping", topic: "orders-eu", header: "EU", want: "eu"},
+		{name: "header matches pattern", topic: "kz.orders", header: "kz", want: "kz"},
+		{name: "header conflicts with mapping", topic: "orders-eu", header: "kz", wantErr: true},
+		{name: "header conflicts with pattern", topic: "kz.orders", header: "eu", wantErr: true},
+		{name: "unmapped topic uses header", topic: "orders", header: "eu", want: "eu"},
+		{name: "unmapped topic without header", topic: "orders", want: domain.DefaultTenant},
+		{name: "invalid header", topic: "orders", header: "not a tenant!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.resolve(tt.topic, tt.header)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidTenant) {
+					t.Fatalf("resolve(%q, %q) = %q, %v, want ErrInvalidTenant", tt.topic, tt.header, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("resolve(%q, %q) = %q, %v, want %q", tt.topic, tt.header, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"os"
+	"time"
+)
+
+// Механизмы SASL
+const (
+	SASLPlain       = "plain"
+	SASLScramSHA256 = "scram-sha-256"
+	SASLScramSHA512 = "scram-sha-512"
+)
+
+const dialTimeout = 10 * time.Second
+
+// SecurityConfig - настройки защищенного подключения к брокерам, общие для producer и consumer
+type SecurityConfig struct {
+	TLS bool
+	// CA для проверки сертификатов брокеров; без файла - системные корневые сертификаты
+	CAFile string
+	// Клиентский сертификат для mTLS
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
+
+	// SASLMechanism: пусто (без аутентификации), plain, scram-sha-256 или scram-sha-512
+	SASLMechanism string
+	Username      string
+	Password      string
+}
+
+// Security - проверенные при старте TLS-конфигурация и механизм SASL
+type Security struct {
+	tls       *tls.Config
+	mechanism sasl.Mechanism
+}
+
+func NewSecurity(cfg SecurityConfig) (*Security, error) {
+	s := &Security{}
+
+	if cfg.TLS {
+		tlsConfig, err := cfg.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		s.tls = tlsConfig
+	}
+
+	switch cfg.SASLMechanism {
+	case "":
+	case SASLPlain:
+		s.mechanism = plain.Mechanism{Username: cfg.Username, Password: cfg.Password}
+	case SASLScramSHA256, SASLScramSHA512:
+		algo := scram.SHA256
+		if cfg.SASLMechanism == SASLScramSHA512 {
+			algo = scram.SHA512
+		}
+		mechanism, err := scram.Mechanism(algo, cfg.Username, cfg.Password)
+		if err != nil {
+			return nil, fmt.Errorf("sasl %s: %v", cfg.SASLMechanism, err)
+		}
+		s.mechanism = mechanism
+	default:
+		return nil, fmt.Errorf("unknown sasl mechanism %q", cfg.SASLMechanism)
+	}
+	return s, nil
+}
+
+func (c SecurityConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		ca, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading kafka ca: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading kafka client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// transport - транспорт для kafka.Writer
+func (s *Security) transport() *kafka.Transport {
+	return &kafka.Transport{
+		DialTimeout: dialTimeout,
+		TLS:         s.tls,
+		SASL:        s.mechanism,
+	}
+}
+
+// dialer - подключение для kafka.Reader и служебных запросов
+func (s *Security) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:       dialTimeout,
+		DualStack:     true,
+		TLS:           s.tls,
+		SASLMechanism: s.mechanism,
+	}
+}
+
+// Description описывает подключение для логов
+func (s *Security) Description() string {
+	protocol := "PLAINTEXT"
+	if s.tls != nil {
+		protocol = "SSL"
+	}
+	if s.mechanism != nil {
+		protocol = "SASL_" + protocol + "/" + s.mechanism.Name()
+	}
+	return protocol
+}
+
+// CheckConnectivity проверяет при старте, что брокер принимает подключение с этими TLS и SASL
+// и что топики существуют и доступны клиенту
+func CheckConnectivity(ctx context.Context, brokers []string, topics []string, security *Security) error {
+	dialer := security.dialer()
+
+	var errs []error
+	for _, broker := range brokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", broker, err))
+			continue
+		}
+		err = checkTopics(conn, topics)
+		_ = conn.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", broker, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no kafka broker reachable with %s: %w", security.Description(), errors.Join(errs...))
+}
+
+func checkTopics(conn *kafka.Conn, topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	partitions, err := conn.ReadPartitions(topics...)
+	if err != nil {
+		return fmt.Errorf("reading topic metadata: %w", err)
+	}
+
+	found := map[string]bool{}
+	for _, p := range partitions {
+		found[p.Topic] = true
+	}
+	for _, topic := range topics {
+		if !found[topic] {
+			return fmt.Errorf("topic %q not found", topic)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var ErrShutdownTimeout = errors.New("shutdown deadline exceeded")
+
+// Hook - компонент приложения. OnStart не должен блокироваться: долгую работу запускают в горутине (см. Worker).
+// OnStop вызывается в обратном порядке запуска.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+type Manager struct {
+	hooks           []Hook
+	shutdownTimeout time.Duration
+	logger          *slog.Logger
+
+	mu    sync.Mutex
+	abort context.CancelCauseFunc
+}
+
+func New(shutdownTimeout time.Duration, logger *slog.Logger) *Manager {
+	return &Manager{
+		shutdownTimeout: shutdownTimeout,
+		logger:          logger,
+	}
+}
+
+func (m *Manager) Append(h Hook) {
+	m.hooks = append(m.hooks, h)
+}
+
+// Abort досрочно запускает остановку приложения, например при падении HTTP-сервера
+func (m *Manager) Abort(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.abort != nil {
+		m.abort(err)
+	}
+}
+
+// Run запускает хуки по порядку, ждет отмены ctx (сигнал) или Abort и останавливает
+// запущенные хуки в обратном порядке с общим дедлайном shutdownTimeout
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	m.mu.Lock()
+	m.abort = cancel
+	m.mu.Unlock()
+
+	started := 0
+	var startErr error
+	for _, h := range m.hooks {
+		if h.OnStart != nil {
+			m.logger.Info("starting component", "component", h.Name)
+			if err := h.OnStart(ctx); err != nil {
+				startErr = fmt.Errorf("start %s: %w", h.Name, err)
+				break
+			}
+		}
+		started++
+	}
+
+	if startErr == nil {
+		<-ctx.Done()
+		if cause := context.Cause(ctx); !errors.Is(cause, context.Canceled) {
+			startErr = cause
+		}
+	}
+	m.logger.Info("shutting down", "reason", context.Cause(ctx))
+
+	stopErr := m.stop(started)
+	return errors.Join(startErr, stopErr)
+}
+
+func (m *Manager) stop(started int) error {
+	ctx, cancel := context.WithTimeoutCause(context.Background(), m.shutdownTimeout, ErrShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := started - 1; i >= 0; i-- {
+		h := m.hooks[i]
+		if h.OnStop == nil {
+			continue
+		}
+
+		start := time.Now()
+		if err := h.OnStop(ctx); err != nil {
+			m.logger.Error("component stop failed", "component", h.Name, "error", err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", h.Name, err))
+			continue
+		}
+		m.logger.Info("component stopped", "component", h.Name, "duration", time.Since(start))
+	}
+
+	if ctx.Err() != nil {
+		errs = append(errs, context.Cause(ctx))
+	}
+	return errors.Join(errs...)
+}
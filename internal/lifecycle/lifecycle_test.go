@@ -0,0 +1,201 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func (r *recorder) hook(name string) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			r.add("start " + name)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func nopLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Полный сценарий: старт по порядку, отмена root-контекста, дообработка сообщения
+// consumer'ом, фиксация оффсета и остановка остальных компонентов в обратном порядке
+func TestRunShutdownSequence(t *testing.T) {
+	rec := &recorder{}
+	m := New(time.Second, nopLogger())
+
+	m.Append(rec.hook("postgres"))
+	m.Append(rec.hook("producer"))
+	m.Append(rec.hook("http"))
+
+	inFlight := make(chan struct{})
+	consumer := NewWorker(func(ctx context.Context) {
+		//Сообщение получено до сигнала и дообрабатывается после него
+		close(inFlight)
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		rec.add("processed")
+	})
+	m.Append(Hook{
+		Name:    "consumer",
+		OnStart: consumer.Start,
+		OnStop: func(ctx context.Context) error {
+			err := consumer.Stop(ctx)
+			rec.add("commit")
+			return err
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.Run(ctx)
+	}()
+
+	<-inFlight
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	want := []string{
+		"start postgres", "start producer", "start http",
+		"processed", "commit",
+		"stop http", "stop producer", "stop postgres",
+	}
+	if got := rec.get(); !equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestRunShutdownDeadline(t *testing.T) {
+	rec := &recorder{}
+	m := New(50*time.Millisecond, nopLogger())
+
+	m.Append(rec.hook("postgres"))
+	m.Append(Hook{
+		Name: "stuck",
+		OnStop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return context.Cause(ctx)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := m.Run(ctx)
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("Run error = %v, want ErrShutdownTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("shutdown took %v, deadline not enforced", elapsed)
+	}
+
+	//Следующие компоненты все равно останавливаются, чтобы освободить ресурсы
+	want := []string{"start postgres", "stop postgres"}
+	if got := rec.get(); !equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestRunAbort(t *testing.T) {
+	rec := &recorder{}
+	m := New(time.Second, nopLogger())
+	errListen := errors.New("listen failed")
+
+	m.Append(rec.hook("postgres"))
+	m.Append(Hook{
+		Name: "http",
+		OnStart: func(ctx context.Context) error {
+			go m.Abort(errListen)
+			return nil
+		},
+	})
+
+	err := m.Run(context.Background())
+	if !errors.Is(err, errListen) {
+		t.Fatalf("Run error = %v, want %v", err, errListen)
+	}
+
+	want := []string{"start postgres", "stop postgres"}
+	if got := rec.get(); !equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestRunStartFailure(t *testing.T) {
+	rec := &recorder{}
+	m := New(time.Second, nopLogger())
+	errStart := errors.New("boom")
+
+	m.Append(rec.hook("postgres"))
+	m.Append(Hook{
+		Name: "producer",
+		OnStart: func(ctx context.Context) error {
+			return errStart
+		},
+		OnStop: func(ctx context.Context) error {
+			rec.add("stop producer")
+			return nil
+		},
+	})
+	m.Append(rec.hook("http"))
+
+	err := m.Run(context.Background())
+	if !errors.Is(err, errStart) {
+		t.Fatalf("Run error = %v, want %v", err, errStart)
+	}
+
+	//Останавливаются только успешно запущенные компоненты
+	want := []string{"start postgres", "stop postgres"}
+	if got := rec.get(); !equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"context"
+)
+
+// Worker запускает блокирующую функцию в горутине и останавливает ее отменой контекста,
+// дожидаясь выхода (например, окончания обработки текущего сообщения)
+type Worker struct {
+	run    func(ctx context.Context)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewWorker(run func(ctx context.Context)) *Worker {
+	return &Worker{
+		run: run,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) error {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+		w.run(ctx)
+	}()
+	return nil
+}
+
+func (w *Worker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// Hook оборачивает Worker в хук жизненного цикла
+func (w *Worker) Hook(name string) Hook {
+	return Hook{
+		Name:    name,
+		OnStart: w.Start,
+		OnStop:  w.Stop,
+	}
+}
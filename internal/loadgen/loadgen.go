@@ -0,0 +1,213 @@
+package loadgen
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Профили нагрузки
+const (
+	ProfileConstant = "constant"
+	ProfileBurst    = "burst"
+	ProfileRamp     = "ramp"
+)
+
+// Отставание планировщика больше этого значения не догоняется всплеском отправок
+const maxLag = time.Second
+
+type Config struct {
+	Rate        float64 // целевая скорость, сообщений в секунду
+	Duration    time.Duration
+	Concurrency int
+	Profile     string
+
+	// Профиль burst: каждые BurstEvery скорость на BurstLength поднимается в BurstFactor раз
+	BurstFactor float64
+	BurstEvery  time.Duration
+	BurstLength time.Duration
+}
+
+func (c Config) validate() error {
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate must be positive, got %v", c.Rate)
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %v", c.Duration)
+	}
+	if c.Concurrency <= 0 {
+		return fmt.Errorf("concurrency must be positive, got %d", c.Concurrency)
+	}
+	switch c.Profile {
+	case ProfileConstant, ProfileRamp:
+	case ProfileBurst:
+		if c.BurstFactor <= 0 || c.BurstEvery <= 0 || c.BurstLength <= 0 {
+			return fmt.Errorf("burst profile requires positive burst factor, interval and length")
+		}
+	default:
+		return fmt.Errorf("unknown profile %q", c.Profile)
+	}
+	return nil
+}
+
+// rateAt возвращает целевую скорость в момент elapsed от начала прогона
+func (c Config) rateAt(elapsed time.Duration) float64 {
+	switch c.Profile {
+	case ProfileBurst:
+		if elapsed%c.BurstEvery < c.BurstLength {
+			return c.Rate * c.BurstFactor
+		}
+	case ProfileRamp:
+		//Линейный рост от 1 msg/s до Rate за время прогона
+		return math.Max(1, c.Rate*float64(elapsed)/float64(c.Duration))
+	}
+	return c.Rate
+}
+
+type Sender interface {
+	SendOrder(ctx context.Context, order *domain.CompleteFakeOrder) error
+}
+
+type Summary struct {
+	Profile     string
+	TargetRate  float64
+	Elapsed     time.Duration
+	Sent        int64
+	Failed      int64
+	Throughput  float64 // успешно отправленных сообщений в секунду
+	LatencyP50  time.Duration
+	LatencyP99  time.Duration
+	LatencyMax  time.Duration
+	ErrorCounts map[string]int64
+}
+
+func (s Summary) String() string {
+	out := fmt.Sprintf("profile=%s target=%.1f msg/s elapsed=%s sent=%d failed=%d throughput=%.1f msg/s latency p50=%s p99=%s max=%s",
+		s.Profile, s.TargetRate, s.Elapsed.Round(time.Millisecond), s.Sent, s.Failed, s.Throughput,
+		s.LatencyP50, s.LatencyP99, s.LatencyMax)
+	for err, count := range s.ErrorCounts {
+		out += fmt.Sprintf("\n  %d x %s", count, err)
+	}
+	return out
+}
+
+type Generator struct {
+	cfg      Config
+	generate func() *domain.CompleteFakeOrder
+	sender   Sender
+	logger   *slog.Logger
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	summary   Summary
+}
+
+func NewGenerator(cfg Config, generate func() *domain.CompleteFakeOrder, sender Sender, logger *slog.Logger) (*Generator, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &Generator{
+		cfg:      cfg,
+		generate: generate,
+		sender:   sender,
+		logger:   logger.With("component", "loadgen"),
+	}, nil
+}
+
+// Run отправляет заказы с целевой скоростью до истечения Duration или отмены ctx.
+// Заказы генерируются в одной горутине, поэтому при одинаковом seed их последовательность повторяется
+func (g *Generator) Run(ctx context.Context) Summary {
+	g.summary = Summary{
+		Profile:     g.cfg.Profile,
+		TargetRate:  g.cfg.Rate,
+		ErrorCounts: map[string]int64{},
+	}
+	g.latencies = nil
+
+	jobs := make(chan *domain.CompleteFakeOrder, g.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < g.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for order := range jobs {
+				g.send(ctx, order)
+			}
+		}()
+	}
+
+	start := time.Now()
+	g.dispatch(ctx, start, jobs)
+	close(jobs)
+	wg.Wait()
+
+	g.summary.Elapsed = time.Since(start)
+	g.summary.Throughput = float64(g.summary.Sent) / g.summary.Elapsed.Seconds()
+	g.summary.LatencyP50, g.summary.LatencyP99, g.summary.LatencyMax = percentiles(g.latencies)
+	return g.summary
+}
+
+func (g *Generator) dispatch(ctx context.Context, start time.Time, jobs chan<- *domain.CompleteFakeOrder) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	next := start
+	for {
+		now := time.Now()
+		if now.Sub(start) >= g.cfg.Duration {
+			return
+		}
+
+		if wait := next.Sub(now); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		} else if -wait > maxLag {
+			g.logger.Warn("producer is behind target rate", "lag", -wait)
+			next = now
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case jobs <- g.generate():
+		}
+
+		next = next.Add(time.Duration(float64(time.Second) / g.cfg.rateAt(next.Sub(start))))
+	}
+}
+
+func (g *Generator) send(ctx context.Context, order *domain.CompleteFakeOrder) {
+	start := time.Now()
+	err := g.sender.SendOrder(ctx, order)
+	latency := time.Since(start)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err != nil {
+		g.summary.Failed++
+		g.summary.ErrorCounts[err.Error()]++
+		return
+	}
+	g.summary.Sent++
+	g.latencies = append(g.latencies, latency)
+}
+
+func percentiles(latencies []time.Duration) (p50, p99, max time.Duration) {
+	if len(latencies) == 0 {
+		return 0, 0, 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	at := func(q float64) time.Duration {
+		return latencies[int(q*float64(len(latencies)-1))]
+	}
+	return at(0.5), at(0.99), latencies[len(latencies)-1]
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type Config struct {
+	Level  string
+	Format string
+}
+
+// redactedKeys - атрибуты с персональными данными, значения которых никогда не попадают в лог
+var redactedKeys = map[string]struct{}{
+	"name":    {},
+	"phone":   {},
+	"email":   {},
+	"address": {},
+}
+
+const redacted = "[REDACTED]"
+
+func New(w io.Writer, cfg Config) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil && cfg.Level != "" {
+		return nil, fmt.Errorf("unknown log level: %q", cfg.Level)
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case FormatText, "":
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format: %q", cfg.Format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if _, ok := redactedKeys[strings.ToLower(a.Key)]; ok && a.Value.Kind() != slog.KindGroup {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// Nop - логгер, который ничего не пишет
+func Nop() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+const namespace = "l0wb"
+
+// Причины ошибок обработки сообщений Kafka
+const (
+	ReasonRead      = "read"
+	ReasonUnmarshal = "unmarshal"
+	ReasonSave      = "save"
+	// Смена статуса пришла раньше заказа и отложена до его сохранения
+	ReasonOrderNotFound = "order_not_found"
+)
+
+var (
+	MessagesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "messages_processed_total",
+		Help:      "Kafka messages successfully processed by the order consumer.",
+	}, []string{"topic"})
+
+	MessagesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "messages_failed_total",
+		Help:      "Kafka messages the order consumer failed to process, by reason.",
+	}, []string{"topic", "reason"})
+
+	CompatShims = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "compat_shims_total",
+		Help:      "Compatibility shims applied while decoding order messages, by kind.",
+	}, []string{"topic", "shim"})
+
+	ConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "lag_messages",
+		Help:      "Messages between the last consumed offset and the partition high watermark.",
+	}, []string{"topic", "partition"})
+
+	ConsumerPaused = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "paused",
+		Help:      "1 while the order consumer is paused manually or by the circuit breaker.",
+	})
+
+	BreakerTrips = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "breaker_trips_total",
+		Help:      "Consumer circuit breaker trips, by cause (db_pool_saturated or error_rate).",
+	}, []string{"cause"})
+
+	ProducerBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "batch_size_messages",
+		Help:      "Messages per batch written to a Kafka partition.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 11),
+	}, []string{"topic"})
+
+	ProducerWriteDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "write_duration_seconds",
+		Help:      "Time from enqueueing a message to its delivery report.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	ProducerMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "messages_total",
+		Help:      "Messages reported by the Kafka writer, by result (delivered or failed).",
+	}, []string{"topic", "result"})
+
+	OutboxEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "events_total",
+		Help:      "Outbox events handed to Kafka by the relay, by event type and result (published or failed).",
+	}, []string{"event_type", "result"})
+
+	OutboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "pending_events",
+		Help:      "Outbox events not yet published to Kafka.",
+	})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of repository methods.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"repository", "method"})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Order cache lookups, by result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	WarmUpDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "warmup_duration_seconds",
+		Help:      "Duration of the last order cache warm-up.",
+	})
+)
+
+// ObserveQuery фиксирует длительность метода репозитория, вызывается через defer
+func ObserveQuery(repository, method string, start time.Time) {
+	DBQueryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+}
+
+func CacheHit(cache string) {
+	CacheRequests.WithLabelValues(cache, "hit").Inc()
+}
+
+func CacheMiss(cache string) {
+	CacheRequests.WithLabelValues(cache, "miss").Inc()
+}
+
+// RegisterCacheSize публикует текущий размер кеша. Повторная регистрация (например, второй
+// экземпляр приложения в тестах) заменяет прежнюю функцию
+func RegisterCacheSize(cache string, size func() int) {
+	gauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Subsystem:   "cache",
+		Name:        "size",
+		Help:        "Number of entries in the cache.",
+		ConstLabels: prometheus.Labels{"cache": cache},
+	}, func() float64 {
+		return float64(size())
+	})
+
+	if err := prometheus.Register(gauge); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registered) {
+			panic(err)
+		}
+		prometheus.Unregister(registered.ExistingCollector)
+		prometheus.MustRegister(gauge)
+	}
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import (
+	"fmt"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// NewMeterProvider отдает OTel-метрики (в том числе HTTP-метрики ogen) через тот же /metrics
+func NewMeterProvider() (*sdkmetric.MeterProvider, error) {
+	exporter, err := prometheus.New()
+	if err != nil {
+		return nil, fmt.Errorf("error creating prometheus exporter: %v", err)
+	}
+	return sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter)), nil
+}
@@ -0,0 +1,47 @@
+package migrate
+
+import (
+	"L0WB/db"
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"log/slog"
+	"strings"
+)
+
+const migrationsDir = "migrations"
+
+// Run выполняет команду goose (up, down, status, version, ...) над встроенными миграциями
+func Run(ctx context.Context, dsn string, command string, args []string, logger *slog.Logger) error {
+	conn, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return fmt.Errorf("error opening database: %v", err)
+	}
+	defer conn.Close()
+
+	goose.SetBaseFS(db.Migrations)
+	goose.SetLogger(gooseLogger{logger: logger})
+	if err := goose.SetDialect("postgres"); err != nil {
+		return fmt.Errorf("error setting dialect: %v", err)
+	}
+
+	if err := goose.RunContext(ctx, command, conn, migrationsDir, args...); err != nil {
+		return fmt.Errorf("error running migrations %s: %v", command, err)
+	}
+	return nil
+}
+
+// gooseLogger направляет вывод goose в slog
+type gooseLogger struct {
+	logger *slog.Logger
+}
+
+func (l gooseLogger) Fatalf(format string, v ...interface{}) {
+	l.logger.Error(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l gooseLogger) Printf(format string, v ...interface{}) {
+	l.logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
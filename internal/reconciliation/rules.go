@@ -0,0 +1,64 @@
+package reconciliation
+
+import (
+	"L0WB/internal/domain"
+	"time"
+)
+
+// TotalsFromOrder собирает суммы заказа для сверки
+func TotalsFromOrder(order *domain.Order) domain.PaymentTotals {
+	totals := domain.PaymentTotals{
+		Currency:     order.Payment.Currency().Code,
+		Amount:       order.Payment.Amount.Amount,
+		GoodsTotal:   order.Payment.GoodsTotal.Amount,
+		DeliveryCost: order.Payment.DeliveryCost.Amount,
+		CustomFee:    order.Payment.CustomFee.Amount,
+	}
+	for _, item := range order.Items {
+		totals.ItemsTotal += item.TotalPrice.Amount
+	}
+	return totals
+}
+
+// Check проверяет финансовые инварианты заказа и возвращает найденные расхождения
+func Check(key domain.OrderKey, totals domain.PaymentTotals, source domain.MismatchSource) []domain.Mismatch {
+	var mismatches []domain.Mismatch
+	now := time.Now()
+
+	if _, err := domain.ParseCurrency(totals.Currency); err != nil {
+		mismatches = append(mismatches, domain.Mismatch{
+			Tenant:     key.Tenant,
+			OrderUID:   key.OrderUID,
+			Rule:       domain.RuleUnknownCurrency,
+			Detail:     totals.Currency,
+			Source:     source,
+			DetectedAt: now,
+		})
+	}
+
+	if expected := totals.GoodsTotal + totals.DeliveryCost + totals.CustomFee; totals.Amount != expected {
+		mismatches = append(mismatches, domain.Mismatch{
+			Tenant:     key.Tenant,
+			OrderUID:   key.OrderUID,
+			Rule:       domain.RuleAmountTotal,
+			Expected:   expected,
+			Actual:     totals.Amount,
+			Source:     source,
+			DetectedAt: now,
+		})
+	}
+
+	if totals.GoodsTotal != totals.ItemsTotal {
+		mismatches = append(mismatches, domain.Mismatch{
+			Tenant:     key.Tenant,
+			OrderUID:   key.OrderUID,
+			Rule:       domain.RuleGoodsTotal,
+			Expected:   totals.ItemsTotal,
+			Actual:     totals.GoodsTotal,
+			Source:     source,
+			DetectedAt: now,
+		})
+	}
+
+	return mismatches
+}
@@ -0,0 +1,200 @@
+package analytics
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// views - материализованные представления, которые обновляет Refresh
+var views = []string{
+	"mv_revenue_daily",
+	"mv_item_sales_daily",
+	"mv_delivery_daily",
+}
+
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+func (r *Repository) Refresh(ctx context.Context) error {
+	defer metrics.ObserveQuery("analytics", "Refresh", time.Now())
+
+	for _, view := range views {
+		if _, err := r.db.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+			return fmt.Errorf("error refreshing %s: %v", view, err)
+		}
+	}
+	return nil
+}
+
+func (r *Repository) Revenue(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RevenuePoint, error) {
+	defer metrics.ObserveQuery("analytics", "Revenue", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT day, currency, provider, sum(orders), sum(revenue), sum(delivery_cost)
+		FROM mv_revenue_daily
+		WHERE tenant = $1 AND day BETWEEN $2 AND $3
+		GROUP BY day, currency, provider
+		ORDER BY day, currency, provider`,
+		tenant, dr.From, dr.To,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying revenue: %v", err)
+	}
+
+	points, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.RevenuePoint, error) {
+		var p domain.RevenuePoint
+		err := row.Scan(&p.Day, &p.Currency, &p.Provider, &p.Orders, &p.Revenue, &p.DeliveryCost)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning revenue: %v", err)
+	}
+	return points, nil
+}
+
+// TopBrands возвращает топ брендов по выручке отдельно в каждой валюте: limit действует на валюту
+func (r *Repository) TopBrands(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.BrandSales, error) {
+	defer metrics.ObserveQuery("analytics", "TopBrands", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT currency, brand, items, revenue
+		FROM (
+			SELECT currency, brand, sum(items) AS items, sum(revenue) AS revenue,
+			       row_number() OVER (PARTITION BY currency ORDER BY sum(revenue) DESC, brand) AS rank
+			FROM mv_item_sales_daily
+			WHERE tenant = $1 AND day BETWEEN $2 AND $3
+			GROUP BY currency, brand
+		) t
+		WHERE rank <= $4
+		ORDER BY currency, rank`,
+		tenant, dr.From, dr.To, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying top brands: %v", err)
+	}
+
+	brands, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.BrandSales, error) {
+		var b domain.BrandSales
+		err := row.Scan(&b.Currency, &b.Brand, &b.Items, &b.Revenue)
+		return b, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning top brands: %v", err)
+	}
+	return brands, nil
+}
+
+// TopItems возвращает топ товаров по продажам отдельно в каждой валюте: limit действует на валюту
+func (r *Repository) TopItems(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.ItemSales, error) {
+	defer metrics.ObserveQuery("analytics", "TopItems", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT currency, nm_id, name, brand, items, revenue
+		FROM (
+			SELECT currency, nm_id, max(name) AS name, max(brand) AS brand, sum(items) AS items, sum(revenue) AS revenue,
+			       row_number() OVER (PARTITION BY currency ORDER BY sum(items) DESC, sum(revenue) DESC, nm_id) AS rank
+			FROM mv_item_sales_daily
+			WHERE tenant = $1 AND day BETWEEN $2 AND $3
+			GROUP BY currency, nm_id
+		) t
+		WHERE rank <= $4
+		ORDER BY currency, rank`,
+		tenant, dr.From, dr.To, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying top items: %v", err)
+	}
+
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.ItemSales, error) {
+		var i domain.ItemSales
+		err := row.Scan(&i.Currency, &i.NmID, &i.Name, &i.Brand, &i.Items, &i.Revenue)
+		return i, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning top items: %v", err)
+	}
+	return items, nil
+}
+
+func (r *Repository) Discount(ctx context.Context, tenant string, dr domain.DateRange) (domain.DiscountStats, error) {
+	defer metrics.ObserveQuery("analytics", "Discount", time.Now())
+
+	var stats domain.DiscountStats
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(sum(items), 0)::BIGINT,
+		       COALESCE(sum(sale_sum)::float8 / NULLIF(sum(items), 0), 0)
+		FROM mv_item_sales_daily
+		WHERE tenant = $1 AND day BETWEEN $2 AND $3`,
+		tenant, dr.From, dr.To,
+	).Scan(&stats.Items, &stats.AverageSale)
+	if err != nil {
+		return domain.DiscountStats{}, fmt.Errorf("error fetching discount stats: %v", err)
+	}
+	return stats, nil
+}
+
+func (r *Repository) DeliveryServices(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.DeliveryServiceShare, error) {
+	defer metrics.ObserveQuery("analytics", "DeliveryServices", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT delivery_service,
+		       sum(orders) AS orders,
+		       sum(orders)::float8 / sum(sum(orders)) OVER () AS share
+		FROM mv_delivery_daily
+		WHERE tenant = $1 AND day BETWEEN $2 AND $3
+		GROUP BY delivery_service
+		ORDER BY orders DESC, delivery_service`,
+		tenant, dr.From, dr.To,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying delivery services: %v", err)
+	}
+
+	shares, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.DeliveryServiceShare, error) {
+		var s domain.DeliveryServiceShare
+		err := row.Scan(&s.DeliveryService, &s.Orders, &s.Share)
+		return s, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning delivery services: %v", err)
+	}
+	return shares, nil
+}
+
+func (r *Repository) Regions(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RegionOrders, error) {
+	defer metrics.ObserveQuery("analytics", "Regions", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT region, city, sum(orders) AS orders
+		FROM mv_delivery_daily
+		WHERE tenant = $1 AND day BETWEEN $2 AND $3
+		GROUP BY region, city
+		ORDER BY orders DESC, region, city`,
+		tenant, dr.From, dr.To,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying regions: %v", err)
+	}
+
+	regions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.RegionOrders, error) {
+		var ro domain.RegionOrders
+		err := row.Scan(&ro.Region, &ro.City, &ro.Orders)
+		return ro, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning regions: %v", err)
+	}
+	return regions, nil
+}
@@ -0,0 +1,132 @@
+package order
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// favouriteBrandsLimit - сколько самых частых брендов попадает в сводку
+const favouriteBrandsLimit = 5
+
+func (r *Repository) ListOrdersByCustomer(ctx context.Context, customer domain.CustomerKey, limit, offset int) ([]domain.Order, error) {
+	defer metrics.ObserveQuery("order", "ListOrdersByCustomer", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, `
+		SELECT order_uid FROM orders
+		WHERE tenant = $1 AND customer_id = $2 AND deleted_at IS NULL
+		ORDER BY date_created DESC
+		LIMIT $3 OFFSET $4`,
+		customer.Tenant, customer.CustomerID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying customer orders: %v", err)
+	}
+	orderUIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("error scanning customer orders: %v", err)
+	}
+
+	orders := make([]domain.Order, 0, len(orderUIDs))
+	for _, orderUID := range orderUIDs {
+		order, err := getOrderByUIDWithTx(ctx, tx, domain.OrderKey{Tenant: customer.Tenant, OrderUID: orderUID})
+		if err != nil {
+			return nil, fmt.Errorf("error getting order %s: %v", orderUID, err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return orders, nil
+}
+
+func (r *Repository) GetCustomerSummary(ctx context.Context, customer domain.CustomerKey) (domain.CustomerSummary, error) {
+	defer metrics.ObserveQuery("order", "GetCustomerSummary", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	summary := domain.CustomerSummary{Tenant: customer.Tenant, CustomerID: customer.CustomerID}
+
+	var first, last pgtype.Timestamp
+	err = tx.QueryRow(ctx, `
+		SELECT count(*), min(date_created), max(date_created) FROM orders
+		WHERE tenant = $1 AND customer_id = $2 AND deleted_at IS NULL`,
+		customer.Tenant, customer.CustomerID,
+	).Scan(&summary.OrdersCount, &first, &last)
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error fetching customer orders stats: %v", err)
+	}
+	if summary.OrdersCount == 0 {
+		return domain.CustomerSummary{}, fmt.Errorf("error fetching customer %s/%s: %w", customer.Tenant, customer.CustomerID, domain.ErrCustomerNotFound)
+	}
+	summary.FirstOrderAt, summary.LastOrderAt = first.Time, last.Time
+
+	rows, err := tx.Query(ctx, `
+		SELECT p.currency, COALESCE(sum(p.amount), 0), count(*)
+		FROM orders o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.tenant = $1 AND o.customer_id = $2 AND o.deleted_at IS NULL
+		GROUP BY p.currency
+		ORDER BY p.currency`,
+		customer.Tenant, customer.CustomerID,
+	)
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error querying customer spend: %v", err)
+	}
+	summary.SpendByCurrency, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.CurrencySpend, error) {
+		var spend domain.CurrencySpend
+		err := row.Scan(&spend.Currency, &spend.Total, &spend.Orders)
+		return spend, err
+	})
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error scanning customer spend: %v", err)
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT i.brand, count(*) AS items
+		FROM orders o
+		JOIN items i ON i.id = ANY(o.item_ids)
+		WHERE o.tenant = $1 AND o.customer_id = $2 AND o.deleted_at IS NULL
+		GROUP BY i.brand
+		ORDER BY items DESC, i.brand
+		LIMIT $3`,
+		customer.Tenant, customer.CustomerID, favouriteBrandsLimit,
+	)
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error querying customer brands: %v", err)
+	}
+	summary.FavouriteBrands, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.BrandCount, error) {
+		var brand domain.BrandCount
+		err := row.Scan(&brand.Brand, &brand.Items)
+		return brand, err
+	})
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error scanning customer brands: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return summary, nil
+}
@@ -7,6 +7,8 @@ import (
 
 type Order struct {
 	OrderUID          uuid.UUID   `db:"order_uid"`
+	Tenant            string      `db:"tenant"`
+	Status            string      `db:"status"`
 	PaymentID         uuid.UUID   `db:"payment_id"`
 	DeliveryID        uuid.UUID   `db:"delivery_id"`
 	ItemIDs           []uuid.UUID `db:"item_ids"`
@@ -20,6 +22,7 @@ type Order struct {
 	SmID              int         `db:"sm_id"`
 	DateCreated       time.Time   `db:"date_created"`
 	OofShard          string      `db:"oof_shard"`
+	SourceOrderUID    string      `db:"source_order_uid"`
 }
 
 type Delivery struct {
@@ -0,0 +1,74 @@
+package order
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// createdEventQuery пишет в outbox снимок только что сохраненного заказа
+const createdEventQuery = `
+INSERT INTO outbox (tenant, order_uid, event_type, payload)
+SELECT o.tenant,
+       o.order_uid,
+       $3,
+       jsonb_build_object(
+           'order', to_jsonb(o),
+           'delivery', to_jsonb(d),
+           'payment', to_jsonb(p),
+           'items', COALESCE((SELECT jsonb_agg(to_jsonb(i)) FROM items i WHERE i.id = ANY(o.item_ids)), '[]'::jsonb)
+       )
+FROM orders o
+LEFT JOIN delivery d ON d.id = o.delivery_id
+LEFT JOIN payments p ON p.id = o.payment_id
+WHERE o.tenant = $1 AND o.order_uid = $2`
+
+// updatedEventQuery пишет в outbox актуальные данные доставки заказов покупателя
+const updatedEventQuery = `
+INSERT INTO outbox (tenant, order_uid, event_type, payload)
+SELECT o.tenant,
+       o.order_uid,
+       $3,
+       jsonb_build_object('order_uid', o.order_uid, 'tenant', o.tenant, 'customer_id', o.customer_id, 'delivery', to_jsonb(d))
+FROM orders o
+JOIN delivery d ON d.id = o.delivery_id
+WHERE o.tenant = $1 AND o.order_uid = ANY($2)`
+
+const statusChangedEventQuery = `
+INSERT INTO outbox (tenant, order_uid, event_type, payload)
+SELECT tenant, uid, $3, jsonb_build_object('order_uid', uid, 'tenant', tenant, 'status', $4::text)
+FROM unnest($1::text[], $2::uuid[]) AS k(tenant, uid)`
+
+func insertCreatedEvent(ctx context.Context, tx pgx.Tx, key domain.OrderKey) error {
+	if _, err := tx.Exec(ctx, createdEventQuery, key.Tenant, key.OrderUID, domain.OrderCreated); err != nil {
+		return fmt.Errorf("error writing outbox event: %v", err)
+	}
+	return nil
+}
+
+func insertUpdatedEvents(ctx context.Context, tx pgx.Tx, tenant string, orderUIDs []uuid.UUID) error {
+	if len(orderUIDs) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, updatedEventQuery, tenant, orderUIDs, domain.OrderUpdated); err != nil {
+		return fmt.Errorf("error writing outbox events: %v", err)
+	}
+	return nil
+}
+
+func insertStatusChangedEvents(ctx context.Context, tx pgx.Tx, keys []domain.OrderKey, status string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	tenants := make([]string, len(keys))
+	orderUIDs := make([]uuid.UUID, len(keys))
+	for i, key := range keys {
+		tenants[i], orderUIDs[i] = key.Tenant, key.OrderUID
+	}
+	if _, err := tx.Exec(ctx, statusChangedEventQuery, tenants, orderUIDs, domain.OrderStatusChanged, status); err != nil {
+		return fmt.Errorf("error writing outbox events: %v", err)
+	}
+	return nil
+}
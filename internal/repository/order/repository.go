@@ -2,12 +2,15 @@ package order
 
 import (
 	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 )
 
 type Repository struct {
@@ -20,7 +23,9 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 	}
 }
 
-func (r *Repository) GetOrder(ctx context.Context, orderUID uuid.UUID) (domain.Order, error) {
+func (r *Repository) GetOrder(ctx context.Context, key domain.OrderKey) (domain.Order, error) {
+	defer metrics.ObserveQuery("order", "GetOrder", time.Now())
+
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return domain.Order{}, fmt.Errorf("error starting transaction: %v", err)
@@ -29,9 +34,9 @@ func (r *Repository) GetOrder(ctx context.Context, orderUID uuid.UUID) (domain.O
 		_ = tx.Rollback(ctx)
 	}()
 
-	order, err := getOrderByUIDWithTx(ctx, tx, orderUID.String())
+	order, err := getOrderByUIDWithTx(ctx, tx, key)
 	if err != nil {
-		return domain.Order{}, fmt.Errorf("error getting order: %v", err)
+		return domain.Order{}, fmt.Errorf("error getting order: %w", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -40,7 +45,9 @@ func (r *Repository) GetOrder(ctx context.Context, orderUID uuid.UUID) (domain.O
 	return order, nil
 }
 
-func (r *Repository) GetAllOrdersByUID(ctx context.Context) ([]uuid.UUID, error) {
+func (r *Repository) GetAllOrdersByUID(ctx context.Context) ([]domain.OrderKey, error) {
+	defer metrics.ObserveQuery("order", "GetAllOrdersByUID", time.Now())
+
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error starting transaction: %v", err)
@@ -48,7 +55,7 @@ func (r *Repository) GetAllOrdersByUID(ctx context.Context) ([]uuid.UUID, error)
 	defer func() {
 		_ = tx.Rollback(ctx)
 	}()
-	query := `SELECT order_uid FROM orders`
+	query := `SELECT tenant, order_uid FROM orders WHERE deleted_at IS NULL`
 
 	rows, err := tx.Query(ctx, query)
 	if err != nil {
@@ -56,19 +63,13 @@ func (r *Repository) GetAllOrdersByUID(ctx context.Context) ([]uuid.UUID, error)
 	}
 	defer rows.Close()
 
-	var orders []uuid.UUID
+	var orders []domain.OrderKey
 	for rows.Next() {
-		var orderUID string
-		if err := rows.Scan(&orderUID); err != nil {
+		var key domain.OrderKey
+		if err := rows.Scan(&key.Tenant, &key.OrderUID); err != nil {
 			return nil, fmt.Errorf("error scanning: %v", err)
 		}
-
-		uid, err := uuid.Parse(orderUID)
-		if err != nil {
-			continue //Пропускаю невалидные ID
-		}
-
-		orders = append(orders, uid)
+		orders = append(orders, key)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -77,9 +78,11 @@ func (r *Repository) GetAllOrdersByUID(ctx context.Context) ([]uuid.UUID, error)
 	return orders, rows.Err()
 }
 
-func getOrderByUIDWithTx(ctx context.Context, tx pgx.Tx, orderUID string) (domain.Order, error) {
+func getOrderByUIDWithTx(ctx context.Context, tx pgx.Tx, key domain.OrderKey) (domain.Order, error) {
 	q, args, err := squirrel.Select(
 		"order_uid",
+		"tenant",
+		"status",
 		"payment_id",
 		"delivery_id",
 		"item_ids",
@@ -93,8 +96,9 @@ func getOrderByUIDWithTx(ctx context.Context, tx pgx.Tx, orderUID string) (domai
 		"sm_id",
 		"date_created",
 		"oof_shard",
+		"COALESCE(source_order_uid, '')",
 	).From("orders").
-		Where(squirrel.Eq{"order_uid": orderUID}).
+		Where(squirrel.Eq{"tenant": key.Tenant, "order_uid": key.OrderUID, "deleted_at": nil}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -105,6 +109,8 @@ func getOrderByUIDWithTx(ctx context.Context, tx pgx.Tx, orderUID string) (domai
 
 	err = tx.QueryRow(ctx, q, args...).Scan(
 		&order.OrderUID,
+		&order.Tenant,
+		&order.Status,
 		&order.PaymentID,
 		&order.DeliveryID,
 		&order.ItemIDs,
@@ -118,7 +124,11 @@ func getOrderByUIDWithTx(ctx context.Context, tx pgx.Tx, orderUID string) (domai
 		&order.SmID,
 		&order.DateCreated,
 		&order.OofShard,
+		&order.SourceOrderUID,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Order{}, fmt.Errorf("error fetching order %s: %w", key, domain.ErrOrderNotFound)
+	}
 	if err != nil {
 		return domain.Order{}, fmt.Errorf("error fetching order: %v", err)
 	}
@@ -247,8 +257,15 @@ func getOrderByUIDWithTx(ctx context.Context, tx pgx.Tx, orderUID string) (domai
 }
 
 func toDomainOrder(dbOrder Order, delivery Delivery, payment Payment, items []Item) domain.Order {
+	currency := domain.CurrencyOf(payment.Currency)
+	money := func(amount int) domain.Money {
+		return domain.Money{Amount: int64(amount), Currency: currency}
+	}
+
 	return domain.Order{
 		ID:                dbOrder.OrderUID,
+		Tenant:            dbOrder.Tenant,
+		Status:            dbOrder.Status,
 		TrackNumber:       dbOrder.TrackNumber,
 		Entry:             dbOrder.Entry,
 		Locale:            dbOrder.Locale,
@@ -259,6 +276,7 @@ func toDomainOrder(dbOrder Order, delivery Delivery, payment Payment, items []It
 		SmID:              dbOrder.SmID,
 		DateCreated:       dbOrder.DateCreated,
 		OofShard:          dbOrder.OofShard,
+		SourceOrderUID:    dbOrder.SourceOrderUID,
 		Delivery: domain.Delivery{
 			Name:    delivery.Name,
 			Phone:   delivery.Phone,
@@ -271,31 +289,30 @@ func toDomainOrder(dbOrder Order, delivery Delivery, payment Payment, items []It
 		Payment: domain.Payment{
 			Transaction:  payment.Transaction,
 			RequestID:    payment.RequestID,
-			Currency:     payment.Currency,
 			Provider:     payment.Provider,
-			Amount:       payment.Amount,
+			Amount:       money(payment.Amount),
 			PaymentDt:    payment.PaymentDt,
 			Bank:         payment.Bank,
-			DeliveryCost: payment.DeliveryCost,
-			GoodsTotal:   payment.GoodsTotal,
-			CustomFee:    payment.CustomFee,
+			DeliveryCost: money(payment.DeliveryCost),
+			GoodsTotal:   money(payment.GoodsTotal),
+			CustomFee:    money(payment.CustomFee),
 		},
-		Items: toDomainItems(items),
+		Items: toDomainItems(items, currency),
 	}
 }
 
-func toDomainItems(dbItems []Item) []domain.Item {
+func toDomainItems(dbItems []Item, currency domain.Currency) []domain.Item {
 	domainItems := make([]domain.Item, len(dbItems))
 	for i, dbItem := range dbItems {
 		domainItems[i] = domain.Item{
 			ChartID:     dbItem.ChartID,
 			TrackNumber: dbItem.TrackNumber,
-			Price:       dbItem.Price,
+			Price:       domain.Money{Amount: int64(dbItem.Price), Currency: currency},
 			RID:         dbItem.RID,
 			Name:        dbItem.Name,
 			Sale:        dbItem.Sale,
 			Size:        dbItem.Size,
-			TotalPrice:  dbItem.TotalPrice,
+			TotalPrice:  domain.Money{Amount: int64(dbItem.TotalPrice), Currency: currency},
 			NmID:        dbItem.NmID,
 			Brand:       dbItem.Brand,
 			Status:      dbItem.Status,
@@ -311,12 +328,12 @@ func toDTOItems(domainItems []domain.Item) []Item {
 			ID:          uuid.New(),
 			ChartID:     domainItem.ChartID,
 			TrackNumber: domainItem.TrackNumber,
-			Price:       domainItem.Price,
+			Price:       int(domainItem.Price.Amount),
 			RID:         domainItem.RID,
 			Name:        domainItem.Name,
 			Sale:        domainItem.Sale,
 			Size:        domainItem.Size,
-			TotalPrice:  domainItem.TotalPrice,
+			TotalPrice:  int(domainItem.TotalPrice.Amount),
 			NmID:        domainItem.NmID,
 			Brand:       domainItem.Brand,
 			Status:      domainItem.Status,
@@ -325,7 +342,68 @@ func toDTOItems(domainItems []domain.Item) []Item {
 	return dtoItems
 }
 
+// upsertOrderSuffix заменяет данные уже сохраненного заказа; xmax = 0 только у вставленной строки,
+// status - статус для применения отложенных смен статуса
+const upsertOrderSuffix = `
+ON CONFLICT (tenant, order_uid) DO UPDATE SET
+    payment_id = EXCLUDED.payment_id,
+    delivery_id = EXCLUDED.delivery_id,
+    item_ids = EXCLUDED.item_ids,
+    track_number = EXCLUDED.track_number,
+    entry = EXCLUDED.entry,
+    locate = EXCLUDED.locate,
+    internal_signature = EXCLUDED.internal_signature,
+    customer_id = EXCLUDED.customer_id,
+    delivery_service = EXCLUDED.delivery_service,
+    shardkey = EXCLUDED.shardkey,
+    sm_id = EXCLUDED.sm_id,
+    date_created = EXCLUDED.date_created,
+    oof_shard = EXCLUDED.oof_shard,
+    source_order_uid = EXCLUDED.source_order_uid
+RETURNING xmax = 0, status`
+
+// orderParts - строки доставки, оплаты и товаров сохраненного заказа
+type orderParts struct {
+	paymentID  uuid.UUID
+	deliveryID uuid.UUID
+	itemIDs    []uuid.UUID
+}
+
+// lockStoredOrder блокирует уже сохраненный заказ до конца транзакции; nil - заказа нет
+func lockStoredOrder(ctx context.Context, tx pgx.Tx, key domain.OrderKey) (*orderParts, error) {
+	var parts orderParts
+	err := tx.QueryRow(ctx,
+		`SELECT payment_id, delivery_id, item_ids FROM orders WHERE tenant = $1 AND order_uid = $2 FOR UPDATE`,
+		key.Tenant, key.OrderUID,
+	).Scan(&parts.paymentID, &parts.deliveryID, &parts.itemIDs)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error locking stored order: %v", err)
+	}
+	return &parts, nil
+}
+
+// deleteOrderParts удаляет доставку, оплату и товары, замененные при повторном сохранении заказа
+func deleteOrderParts(ctx context.Context, tx pgx.Tx, parts orderParts) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM delivery WHERE id = $1`, parts.deliveryID); err != nil {
+		return fmt.Errorf("error deleting replaced delivery: %v", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM payments WHERE id = $1`, parts.paymentID); err != nil {
+		return fmt.Errorf("error deleting replaced payment: %v", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM items WHERE id = ANY($1)`, parts.itemIDs); err != nil {
+		return fmt.Errorf("error deleting replaced items: %v", err)
+	}
+	return nil
+}
+
+// SaveOrder сохраняет заказ. Повторное сохранение того же заказа (replay в режиме apply)
+// заменяет его данные вместе с доставкой, оплатой и товарами: статус и пометка удаления
+// остаются прежними, вместо order.created пишется order.updated
 func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	defer metrics.ObserveQuery("order", "SaveOrder", time.Now())
 
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -337,6 +415,19 @@ func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
 
 	paymentID := uuid.New()
 	deliveryID := uuid.New()
+	tenant := order.Tenant
+	if tenant == "" {
+		tenant = domain.DefaultTenant
+	}
+
+	key := domain.OrderKey{Tenant: tenant, OrderUID: order.ID}
+	if err := lockOrderKey(ctx, tx, key); err != nil {
+		return err
+	}
+	previous, err := lockStoredOrder(ctx, tx, key)
+	if err != nil {
+		return err
+	}
 
 	qdelivery := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
 		Insert("delivery").
@@ -355,7 +446,7 @@ func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
 	if err != nil {
 		return fmt.Errorf("error building query delivery: %v", err)
 	}
-	_, err = r.db.Exec(ctx, query, args...)
+	_, err = tx.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("error saving delivery: %v", err)
 	}
@@ -367,20 +458,20 @@ func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
 			paymentID,
 			order.Payment.Transaction,
 			order.Payment.RequestID,
-			order.Payment.Currency,
+			order.Payment.Currency().Code,
 			order.Payment.Provider,
-			order.Payment.Amount,
+			order.Payment.Amount.Amount,
 			order.Payment.PaymentDt,
 			order.Payment.Bank,
-			order.Payment.DeliveryCost,
-			order.Payment.GoodsTotal,
-			order.Payment.CustomFee,
+			order.Payment.DeliveryCost.Amount,
+			order.Payment.GoodsTotal.Amount,
+			order.Payment.CustomFee.Amount,
 		)
 	query, args, err = qpayment.ToSql()
 	if err != nil {
 		return fmt.Errorf("error building query payment: %v", err)
 	}
-	_, err = r.db.Exec(ctx, query, args...)
+	_, err = tx.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("error saving payment: %v", err)
 	}
@@ -410,7 +501,7 @@ func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
 		if err != nil {
 			return fmt.Errorf("error building query item: %v", err)
 		}
-		_, err = r.db.Exec(ctx, query, args...)
+		_, err = tx.Exec(ctx, query, args...)
 		if err != nil {
 			return fmt.Errorf("error saving item: %v", err)
 		}
@@ -418,9 +509,10 @@ func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
 
 	qorder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
 		Insert("orders").
-		Columns("order_uid", "payment_id", "delivery_id", "item_ids", "track_number", "entry", "locate", "internal_signature", "customer_id", "delivery_service", "shardkey", "sm_id", "date_created", "oof_shard").
+		Columns("order_uid", "tenant", "payment_id", "delivery_id", "item_ids", "track_number", "entry", "locate", "internal_signature", "customer_id", "delivery_service", "shardkey", "sm_id", "date_created", "oof_shard", "source_order_uid").
 		Values(
 			order.ID,
+			tenant,
 			paymentID,
 			deliveryID,
 			itemIDs,
@@ -434,17 +526,41 @@ func (r *Repository) SaveOrder(ctx context.Context, order *domain.Order) error {
 			order.SmID,
 			order.DateCreated,
 			order.OofShard,
-		)
+			squirrel.Expr("NULLIF(?, '')", order.SourceOrderUID),
+		).
+		Suffix(upsertOrderSuffix)
 
 	query, args, err = qorder.ToSql()
 	if err != nil {
 		return fmt.Errorf("error building query orders: %v", err)
 	}
-	_, err = r.db.Exec(ctx, query, args...)
-	if err != nil {
+	var inserted bool
+	var status string
+	if err := tx.QueryRow(ctx, query, args...).Scan(&inserted, &status); err != nil {
 		return fmt.Errorf("error saving orders: %v", err)
 	}
 
+	//Событие пишется в той же транзакции: заказ без события (и наоборот) не сохранится
+	if inserted {
+		if err := insertCreatedEvent(ctx, tx, key); err != nil {
+			return err
+		}
+		if err := applyParkedStatusUpdates(ctx, tx, key, status); err != nil {
+			return err
+		}
+	} else {
+		//Параллельные сохранения заказа сериализует lockOrderKey, так что заменяемые строки известны
+		if previous == nil {
+			return fmt.Errorf("error saving order %s: stored order was not locked", key)
+		}
+		if err := deleteOrderParts(ctx, tx, *previous); err != nil {
+			return err
+		}
+		if err := insertUpdatedEvents(ctx, tx, tenant, []uuid.UUID{order.ID}); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("error committing transaction: %v", err)
 	}
@@ -0,0 +1,215 @@
+package order
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// purgeQuery удаляет заказы старше $1 вместе с доставкой, оплатой и товарами
+const purgeQuery = `
+WITH expired AS (
+    DELETE FROM orders WHERE date_created < $1
+    RETURNING tenant, order_uid, customer_id, payment_id, delivery_id, item_ids
+), deleted_delivery AS (
+    DELETE FROM delivery WHERE id IN (SELECT delivery_id FROM expired)
+), deleted_payments AS (
+    DELETE FROM payments WHERE id IN (SELECT payment_id FROM expired)
+), deleted_items AS (
+    DELETE FROM items WHERE id IN (SELECT unnest(item_ids) FROM expired)
+)
+SELECT tenant, order_uid, customer_id FROM expired`
+
+// archiveQuery сохраняет снимок заказа в orders_archive перед удалением
+const archiveQuery = `
+INSERT INTO orders_archive (tenant, order_uid, customer_id, date_created, payload)
+SELECT o.tenant,
+       o.order_uid,
+       o.customer_id,
+       o.date_created,
+       jsonb_build_object(
+           'order', to_jsonb(o),
+           'delivery', to_jsonb(d),
+           'payment', to_jsonb(p),
+           'items', COALESCE((SELECT jsonb_agg(to_jsonb(i)) FROM items i WHERE i.id = ANY(o.item_ids)), '[]'::jsonb)
+       )
+FROM orders o
+LEFT JOIN delivery d ON d.id = o.delivery_id
+LEFT JOIN payments p ON p.id = o.payment_id
+WHERE o.date_created < $1
+ON CONFLICT (tenant, order_uid) DO NOTHING`
+
+const eraseQuery = `
+UPDATE delivery
+SET name = $3, phone = $3, address = $3, email = $3, erased_at = NOW()
+WHERE id IN (SELECT delivery_id FROM orders WHERE tenant = $1 AND customer_id = $2)`
+
+// eraseArchiveQuery затирает доставку в снимках архивных заказов покупателя
+const eraseArchiveQuery = `
+UPDATE orders_archive
+SET payload = jsonb_set(payload, '{delivery}', payload->'delivery' ||
+        jsonb_build_object('name', $3::text, 'phone', $3::text, 'address', $3::text, 'email', $3::text, 'erased_at', NOW()))
+WHERE tenant = $1 AND customer_id = $2 AND jsonb_typeof(payload->'delivery') = 'object'`
+
+// eraseOutboxQuery затирает доставку в событиях outbox по заказам покупателя, включая
+// уже опубликованные, но еще не удаленные очисткой
+const eraseOutboxQuery = `
+UPDATE outbox
+SET payload = jsonb_set(payload, '{delivery}', payload->'delivery' ||
+        jsonb_build_object('name', $3::text, 'phone', $3::text, 'address', $3::text, 'email', $3::text))
+WHERE tenant = $1 AND jsonb_typeof(payload->'delivery') = 'object'
+  AND order_uid IN (SELECT order_uid FROM orders WHERE tenant = $1 AND customer_id = $2
+                    UNION SELECT order_uid FROM orders_archive WHERE tenant = $1 AND customer_id = $2)`
+
+// SoftDeleteOrder помечает заказ удаленным и возвращает его покупателя
+func (r *Repository) SoftDeleteOrder(ctx context.Context, key domain.OrderKey) (domain.CustomerKey, error) {
+	defer metrics.ObserveQuery("order", "SoftDeleteOrder", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.CustomerKey{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	customer := domain.CustomerKey{Tenant: key.Tenant}
+	err = tx.QueryRow(ctx,
+		`UPDATE orders SET deleted_at = NOW() WHERE tenant = $1 AND order_uid = $2 AND deleted_at IS NULL RETURNING customer_id`,
+		key.Tenant, key.OrderUID,
+	).Scan(&customer.CustomerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.CustomerKey{}, fmt.Errorf("error deleting order %s: %w", key, domain.ErrOrderNotFound)
+	}
+	if err != nil {
+		return domain.CustomerKey{}, fmt.Errorf("error deleting order: %v", err)
+	}
+
+	if err := insertStatusChangedEvents(ctx, tx, []domain.OrderKey{key}, domain.OrderStatusDeleted); err != nil {
+		return domain.CustomerKey{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.CustomerKey{}, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return customer, nil
+}
+
+// ApplyRetention удаляет или архивирует заказы старше before и возвращает их ключи
+// и покупателей без повторов
+func (r *Repository) ApplyRetention(ctx context.Context, mode domain.RetentionMode, before time.Time) ([]domain.OrderKey, []domain.CustomerKey, error) {
+	defer metrics.ObserveQuery("order", "ApplyRetention", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if mode == domain.RetentionModeArchive {
+		if _, err := tx.Exec(ctx, archiveQuery, before); err != nil {
+			return nil, nil, fmt.Errorf("error archiving orders: %v", err)
+		}
+	}
+
+	//Смены статуса заказов, которые так и не пришли (или уже удалены), дольше срока хранения не ждут
+	if _, err := tx.Exec(ctx, `DELETE FROM parked_status_updates WHERE parked_at < $1`, before); err != nil {
+		return nil, nil, fmt.Errorf("error deleting parked status updates: %v", err)
+	}
+
+	rows, err := tx.Query(ctx, purgeQuery, before)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error purging orders: %v", err)
+	}
+	var (
+		keys      []domain.OrderKey
+		customers []domain.CustomerKey
+		key       domain.OrderKey
+		customer  domain.CustomerKey
+		seen      = map[domain.CustomerKey]bool{}
+	)
+	_, err = pgx.ForEachRow(rows, []any{&key.Tenant, &key.OrderUID, &customer.CustomerID}, func() error {
+		keys = append(keys, key)
+		customer.Tenant = key.Tenant
+		if !seen[customer] {
+			seen[customer] = true
+			customers = append(customers, customer)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error scanning purged orders: %v", err)
+	}
+
+	status := domain.OrderStatusPurged
+	if mode == domain.RetentionModeArchive {
+		status = domain.OrderStatusArchived
+	}
+	if err := insertStatusChangedEvents(ctx, tx, keys, status); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return keys, customers, nil
+}
+
+func (r *Repository) EraseCustomerPII(ctx context.Context, customer domain.CustomerKey) (domain.ErasureReport, error) {
+	defer metrics.ObserveQuery("order", "EraseCustomerPII", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, `SELECT order_uid FROM orders WHERE tenant = $1 AND customer_id = $2`, customer.Tenant, customer.CustomerID)
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error querying customer orders: %v", err)
+	}
+	orderUIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error scanning customer orders: %v", err)
+	}
+
+	tag, err := tx.Exec(ctx, eraseQuery, customer.Tenant, customer.CustomerID, domain.ErasedValue)
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error erasing delivery: %v", err)
+	}
+	archiveTag, err := tx.Exec(ctx, eraseArchiveQuery, customer.Tenant, customer.CustomerID, domain.ErasedValue)
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error erasing archived orders: %v", err)
+	}
+	outboxTag, err := tx.Exec(ctx, eraseOutboxQuery, customer.Tenant, customer.CustomerID, domain.ErasedValue)
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error erasing outbox events: %v", err)
+	}
+
+	if err := insertUpdatedEvents(ctx, tx, customer.Tenant, orderUIDs); err != nil {
+		return domain.ErasureReport{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return domain.ErasureReport{
+		Tenant:               customer.Tenant,
+		CustomerID:           customer.CustomerID,
+		OrderUIDs:            orderUIDs,
+		DeliveriesAnonymised: tag.RowsAffected(),
+		ArchivedAnonymised:   archiveTag.RowsAffected(),
+		EventsAnonymised:     outboxTag.RowsAffected(),
+		ErasedAt:             time.Now(),
+	}, nil
+}
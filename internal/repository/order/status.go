@@ -0,0 +1,123 @@
+package order
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"sort"
+	"time"
+)
+
+const statusUpdatedEventQuery = `
+INSERT INTO outbox (tenant, order_uid, event_type, payload)
+VALUES ($3, $1, $2, jsonb_build_object('order_uid', $1::uuid, 'tenant', $3::text, 'status', $4::text, 'previous_status', $5::text, 'reason', $6::text))`
+
+// UpdateOrderStatus меняет статус заказа витрины и пишет событие order.status_changed в той же транзакции.
+// Возвращает покупателя заказа. Смена статуса еще не сохраненного заказа откладывается в parked_status_updates
+// до его сохранения (SaveOrder), ошибка при этом оборачивает domain.ErrOrderNotFound
+func (r *Repository) UpdateOrderStatus(ctx context.Context, update domain.StatusUpdate) (domain.CustomerKey, error) {
+	defer metrics.ObserveQuery("order", "UpdateOrderStatus", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.CustomerKey{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := lockOrderKey(ctx, tx, update.Key()); err != nil {
+		return domain.CustomerKey{}, err
+	}
+
+	var previous string
+	customer := domain.CustomerKey{Tenant: update.Tenant}
+	err = tx.QueryRow(ctx,
+		`SELECT status, customer_id FROM orders WHERE order_uid = $1 AND tenant = $2 AND deleted_at IS NULL FOR UPDATE`,
+		update.OrderUID, update.Tenant,
+	).Scan(&previous, &customer.CustomerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO parked_status_updates (tenant, order_uid, status, reason) VALUES ($1, $2, $3, NULLIF($4, ''))`,
+			update.Tenant, update.OrderUID, update.Status, update.Reason,
+		); err != nil {
+			return domain.CustomerKey{}, fmt.Errorf("error parking status update: %v", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return domain.CustomerKey{}, fmt.Errorf("error committing transaction: %v", err)
+		}
+		return domain.CustomerKey{}, fmt.Errorf("status update of order %s parked: %w", update.OrderUID, domain.ErrOrderNotFound)
+	}
+	if err != nil {
+		return domain.CustomerKey{}, fmt.Errorf("error querying order status: %v", err)
+	}
+
+	if err := setOrderStatus(ctx, tx, update, previous); err != nil {
+		return domain.CustomerKey{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.CustomerKey{}, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return customer, nil
+}
+
+// setOrderStatus меняет статус заказа с previous на update.Status и пишет событие; тот же статус не меняется
+func setOrderStatus(ctx context.Context, tx pgx.Tx, update domain.StatusUpdate, previous string) error {
+	if previous == update.Status {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `UPDATE orders SET status = $3 WHERE order_uid = $1 AND tenant = $2`, update.OrderUID, update.Tenant, update.Status); err != nil {
+		return fmt.Errorf("error updating order status: %v", err)
+	}
+	if _, err := tx.Exec(ctx, statusUpdatedEventQuery,
+		update.OrderUID, domain.OrderStatusChanged, update.Tenant, update.Status, previous, update.Reason,
+	); err != nil {
+		return fmt.Errorf("error writing outbox event: %v", err)
+	}
+	return nil
+}
+
+// applyParkedStatusUpdates применяет к только что сохраненному заказу отложенные смены статуса в порядке прихода
+func applyParkedStatusUpdates(ctx context.Context, tx pgx.Tx, key domain.OrderKey, status string) error {
+	rows, err := tx.Query(ctx,
+		`DELETE FROM parked_status_updates WHERE tenant = $1 AND order_uid = $2 RETURNING id, status, COALESCE(reason, '')`,
+		key.Tenant, key.OrderUID,
+	)
+	if err != nil {
+		return fmt.Errorf("error taking parked status updates: %v", err)
+	}
+	type parked struct {
+		id     int64
+		update domain.StatusUpdate
+	}
+	updates, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (parked, error) {
+		p := parked{update: domain.StatusUpdate{Tenant: key.Tenant, OrderUID: key.OrderUID}}
+		err := row.Scan(&p.id, &p.update.Status, &p.update.Reason)
+		return p, err
+	})
+	if err != nil {
+		return fmt.Errorf("error scanning parked status updates: %v", err)
+	}
+	sort.Slice(updates, func(i, j int) bool { return updates[i].id < updates[j].id })
+
+	for _, p := range updates {
+		if err := setOrderStatus(ctx, tx, p.update, status); err != nil {
+			return err
+		}
+		status = p.update.Status
+	}
+	return nil
+}
+
+// lockOrderKey сериализует сохранение заказа и смену его статуса до конца транзакции: без этого смена статуса,
+// пришедшая во время сохранения заказа, могла бы отложиться уже после того, как заказ забрал отложенные
+func lockOrderKey(ctx context.Context, tx pgx.Tx, key domain.OrderKey) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1 || '/' || $2::text, 0))`, key.Tenant, key.OrderUID); err != nil {
+		return fmt.Errorf("error locking order %s: %v", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// pendingQuery блокирует очередную пачку неопубликованных событий.
+// SKIP LOCKED позволяет нескольким репликам разбирать outbox параллельно, не дожидаясь друг друга
+const pendingQuery = `
+SELECT id, tenant, order_uid, event_type, payload, created_at, attempts
+FROM outbox
+WHERE published_at IS NULL
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED`
+
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// PublishPending передает в publish до limit неопубликованных событий и отмечает их опубликованными.
+// Строки остаются заблокированными до конца публикации; при ошибке publish события остаются в очереди
+// со счетчиком попыток. Если транзакция не зафиксируется после успешной публикации, события уйдут повторно
+func (r *Repository) PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, events []domain.OutboxEvent) error) (int, error) {
+	defer metrics.ObserveQuery("outbox", "PublishPending", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, pendingQuery, limit)
+	if err != nil {
+		return 0, fmt.Errorf("error querying outbox: %v", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.OutboxEvent, error) {
+		var e domain.OutboxEvent
+		err := row.Scan(&e.ID, &e.Tenant, &e.OrderUID, &e.Type, &e.Payload, &e.CreatedAt, &e.Attempts)
+		return e, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error scanning outbox: %v", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+
+	if publishErr := publish(ctx, events); publishErr != nil {
+		if _, err := tx.Exec(ctx,
+			`UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = ANY($1)`,
+			ids, publishErr.Error(),
+		); err != nil {
+			return 0, fmt.Errorf("error recording outbox failure: %v", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return 0, fmt.Errorf("error committing transaction: %v", err)
+		}
+		return 0, publishErr
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = ANY($1)`,
+		ids,
+	); err != nil {
+		return 0, fmt.Errorf("error marking outbox published: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return len(events), nil
+}
+
+// DeletePublished удаляет события, опубликованные раньше before
+func (r *Repository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	defer metrics.ObserveQuery("outbox", "DeletePublished", time.Now())
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM outbox WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting published outbox events: %v", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Pending возвращает число неопубликованных событий
+func (r *Repository) Pending(ctx context.Context) (int64, error) {
+	defer metrics.ObserveQuery("outbox", "Pending", time.Now())
+
+	var count int64
+	if err := r.db.QueryRow(ctx, `SELECT count(*) FROM outbox WHERE published_at IS NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting outbox: %v", err)
+	}
+	return count, nil
+}
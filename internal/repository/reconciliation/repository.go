@@ -0,0 +1,161 @@
+package reconciliation
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ReplaceMismatches заменяет расхождения по заказам keys на найденные заново
+func (r *Repository) ReplaceMismatches(ctx context.Context, keys []domain.OrderKey, mismatches []domain.Mismatch) error {
+	defer metrics.ObserveQuery("reconciliation", "ReplaceMismatches", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tenants := make([]string, len(keys))
+	orderUIDs := make([]uuid.UUID, len(keys))
+	for i, key := range keys {
+		tenants[i], orderUIDs[i] = key.Tenant, key.OrderUID
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM order_mismatches m
+		USING unnest($1::text[], $2::uuid[]) AS k(tenant, order_uid)
+		WHERE m.tenant = k.tenant AND m.order_uid = k.order_uid`,
+		tenants, orderUIDs,
+	); err != nil {
+		return fmt.Errorf("error deleting mismatches: %v", err)
+	}
+
+	if len(mismatches) > 0 {
+		q := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+			Insert("order_mismatches").
+			Columns("tenant", "order_uid", "rule", "expected", "actual", "detail", "source", "detected_at")
+		for _, m := range mismatches {
+			q = q.Values(m.Tenant, m.OrderUID, m.Rule, m.Expected, m.Actual, squirrel.Expr("NULLIF(?, '')", m.Detail), m.Source, m.DetectedAt)
+		}
+
+		query, args, err := q.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building query mismatches: %v", err)
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("error saving mismatches: %v", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// ScanTotals постранично отдает суммы заказов, упорядоченных по витрине и order_uid
+func (r *Repository) ScanTotals(ctx context.Context, after domain.OrderKey, limit int) ([]domain.OrderTotals, error) {
+	defer metrics.ObserveQuery("reconciliation", "ScanTotals", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT o.tenant,
+		       o.order_uid,
+		       COALESCE(p.currency, ''),
+		       COALESCE(p.amount, 0),
+		       COALESCE(p.goods_total, 0),
+		       COALESCE(p.delivery_cost, 0),
+		       COALESCE(p.custom_fee, 0),
+		       COALESCE((SELECT sum(i.total_price) FROM items i WHERE i.id = ANY(o.item_ids)), 0)
+		FROM orders o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE (o.tenant, o.order_uid) > ($1, $2) AND o.deleted_at IS NULL
+		ORDER BY o.tenant, o.order_uid
+		LIMIT $3`,
+		after.Tenant, after.OrderUID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying totals: %v", err)
+	}
+
+	totals, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.OrderTotals, error) {
+		var t domain.OrderTotals
+		err := row.Scan(
+			&t.Order.Tenant,
+			&t.Order.OrderUID,
+			&t.Totals.Currency,
+			&t.Totals.Amount,
+			&t.Totals.GoodsTotal,
+			&t.Totals.DeliveryCost,
+			&t.Totals.CustomFee,
+			&t.Totals.ItemsTotal,
+		)
+		return t, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning totals: %v", err)
+	}
+	return totals, nil
+}
+
+func (r *Repository) Report(ctx context.Context, limit, offset int) (domain.MismatchReport, error) {
+	defer metrics.ObserveQuery("reconciliation", "Report", time.Now())
+
+	var report domain.MismatchReport
+
+	rows, err := r.db.Query(ctx, `
+		SELECT rule, count(*) FROM order_mismatches
+		GROUP BY rule
+		ORDER BY rule`)
+	if err != nil {
+		return domain.MismatchReport{}, fmt.Errorf("error querying mismatch counts: %v", err)
+	}
+	report.ByRule, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.RuleCount, error) {
+		var rc domain.RuleCount
+		err := row.Scan(&rc.Rule, &rc.Orders)
+		return rc, err
+	})
+	if err != nil {
+		return domain.MismatchReport{}, fmt.Errorf("error scanning mismatch counts: %v", err)
+	}
+	for _, rc := range report.ByRule {
+		report.Total += rc.Orders
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT tenant, order_uid, rule, expected, actual, COALESCE(detail, ''), source, detected_at FROM order_mismatches
+		ORDER BY detected_at DESC, id DESC
+		LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return domain.MismatchReport{}, fmt.Errorf("error querying mismatches: %v", err)
+	}
+	report.Mismatches, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Mismatch, error) {
+		var m domain.Mismatch
+		err := row.Scan(&m.Tenant, &m.OrderUID, &m.Rule, &m.Expected, &m.Actual, &m.Detail, &m.Source, &m.DetectedAt)
+		return m, err
+	})
+	if err != nil {
+		return domain.MismatchReport{}, fmt.Errorf("error scanning mismatches: %v", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultAnalyticsPeriod = 30 * 24 * time.Hour
+	defaultTopLimit        = 10
+	maxTopLimit            = 100
+)
+
+type IAnalyticsRepository interface {
+	Refresh(ctx context.Context) error
+	Revenue(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RevenuePoint, error)
+	TopBrands(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.BrandSales, error)
+	TopItems(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.ItemSales, error)
+	Discount(ctx context.Context, tenant string, dr domain.DateRange) (domain.DiscountStats, error)
+	DeliveryServices(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.DeliveryServiceShare, error)
+	Regions(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RegionOrders, error)
+}
+
+type AnalyticsService struct {
+	repo   IAnalyticsRepository
+	logger *slog.Logger
+}
+
+func NewAnalyticsService(repo IAnalyticsRepository, logger *slog.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// normalizeRange подставляет последние 30 дней, если границы не заданы
+func normalizeRange(dr domain.DateRange) (domain.DateRange, error) {
+	if dr.To.IsZero() {
+		dr.To = time.Now()
+	}
+	if dr.From.IsZero() {
+		dr.From = dr.To.Add(-defaultAnalyticsPeriod)
+	}
+	if dr.From.After(dr.To) {
+		return domain.DateRange{}, fmt.Errorf("invalid date range: from %s is after to %s",
+			dr.From.Format(time.DateOnly), dr.To.Format(time.DateOnly))
+	}
+	return dr, nil
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return defaultTopLimit
+	}
+	if limit > maxTopLimit {
+		return maxTopLimit
+	}
+	return limit
+}
+
+func (s *AnalyticsService) Revenue(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RevenuePoint, error) {
+	dr, err := normalizeRange(dr)
+	if err != nil {
+		return nil, fmt.Errorf("Revenue: %w", err)
+	}
+	return s.repo.Revenue(ctx, tenant, dr)
+}
+
+func (s *AnalyticsService) TopBrands(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.BrandSales, error) {
+	dr, err := normalizeRange(dr)
+	if err != nil {
+		return nil, fmt.Errorf("TopBrands: %w", err)
+	}
+	return s.repo.TopBrands(ctx, tenant, dr, normalizeLimit(limit))
+}
+
+func (s *AnalyticsService) TopItems(ctx context.Context, tenant string, dr domain.DateRange, limit int) ([]domain.ItemSales, error) {
+	dr, err := normalizeRange(dr)
+	if err != nil {
+		return nil, fmt.Errorf("TopItems: %w", err)
+	}
+	return s.repo.TopItems(ctx, tenant, dr, normalizeLimit(limit))
+}
+
+func (s *AnalyticsService) Discount(ctx context.Context, tenant string, dr domain.DateRange) (domain.DiscountStats, error) {
+	dr, err := normalizeRange(dr)
+	if err != nil {
+		return domain.DiscountStats{}, fmt.Errorf("Discount: %w", err)
+	}
+	return s.repo.Discount(ctx, tenant, dr)
+}
+
+func (s *AnalyticsService) DeliveryServices(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.DeliveryServiceShare, error) {
+	dr, err := normalizeRange(dr)
+	if err != nil {
+		return nil, fmt.Errorf("DeliveryServices: %w", err)
+	}
+	return s.repo.DeliveryServices(ctx, tenant, dr)
+}
+
+func (s *AnalyticsService) Regions(ctx context.Context, tenant string, dr domain.DateRange) ([]domain.RegionOrders, error) {
+	dr, err := normalizeRange(dr)
+	if err != nil {
+		return nil, fmt.Errorf("Regions: %w", err)
+	}
+	return s.repo.Regions(ctx, tenant, dr)
+}
+
+// RunRefresh периодически обновляет материализованные представления до отмены контекста
+func (s *AnalyticsService) RunRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		if err := s.repo.Refresh(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "analytics refresh failed", "error", err)
+		} else {
+			s.logger.InfoContext(ctx, "analytics views refreshed", "duration", time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("stopping analytics refresher")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+)
+
+const (
+	defaultCustomerOrdersLimit = 20
+	maxCustomerOrdersLimit     = 100
+)
+
+func (s *Service) ListCustomerOrders(ctx context.Context, customer domain.CustomerKey, limit, offset int) ([]domain.Order, error) {
+	if limit <= 0 {
+		limit = defaultCustomerOrdersLimit
+	}
+	if limit > maxCustomerOrdersLimit {
+		limit = maxCustomerOrdersLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	orders, err := s.repo.ListOrdersByCustomer(ctx, customer, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("ListCustomerOrders: %w", err)
+	}
+	return orders, nil
+}
+
+func (s *Service) GetCustomerSummary(ctx context.Context, customer domain.CustomerKey) (domain.CustomerSummary, error) {
+	if summary, exist := s.summaryCache.Get(customer); exist {
+		metrics.CacheHit("customer_summaries")
+		s.logger.DebugContext(ctx, "cache hit for customer summary", "customer_id", customer.CustomerID, "tenant", customer.Tenant)
+		return summary, nil
+	}
+
+	metrics.CacheMiss("customer_summaries")
+	summary, err := s.repo.GetCustomerSummary(ctx, customer)
+	if err != nil {
+		return domain.CustomerSummary{}, fmt.Errorf("GetCustomerSummary: %w", err)
+	}
+
+	s.summaryCache.Set(customer, summary)
+	return summary, nil
+}
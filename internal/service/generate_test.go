@@ -0,0 +1,66 @@
+package service
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeGenerator struct{}
+
+func (fakeGenerator) GenerateFakeOrders(strategy string, count int) ([]*domain.CompleteFakeOrder, error) {
+	orders := make([]*domain.CompleteFakeOrder, count)
+	for i := range orders {
+		orders[i] = &domain.CompleteFakeOrder{}
+	}
+	return orders, nil
+}
+
+type countingSender struct {
+	sent int
+}
+
+func (s *countingSender) SendOrder(ctx context.Context, order *domain.CompleteFakeOrder) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendOrders(ctx context.Context, orders []*domain.CompleteFakeOrder) error {
+	s.sent += len(orders)
+	return nil
+}
+
+func TestSendInterval(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want time.Duration
+	}{
+		{rate: 1, want: time.Second},
+		{rate: 4, want: 250 * time.Millisecond},
+		{rate: 1e9, want: time.Nanosecond},
+		{rate: 1e12, want: time.Nanosecond},
+	}
+
+	for _, tt := range tests {
+		if got := sendInterval(tt.rate); got != tt.want {
+			t.Errorf("sendInterval(%g) = %s, want %s", tt.rate, got, tt.want)
+		}
+	}
+}
+
+// Большой rate не должен ронять генерацию нулевым интервалом тикера
+func TestGenerateFakeOrdersLargeRate(t *testing.T) {
+	sender := &countingSender{}
+	s := NewService(nil, nil, nil, nil, fakeGenerator{}, sender, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	generated, err := s.GenerateFakeOrdersFromKafka(context.Background(), "", 5, 1e12)
+	if err != nil {
+		t.Fatalf("GenerateFakeOrdersFromKafka: %v", err)
+	}
+	if generated != 5 || sender.sent != 5 {
+		t.Errorf("generated = %d, sent = %d, want 5", generated, sender.sent)
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type IOutboxRepository interface {
+	PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, events []domain.OutboxEvent) error) (int, error)
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+	Pending(ctx context.Context) (int64, error)
+}
+
+type IEventPublisher interface {
+	PublishEvents(ctx context.Context, events []domain.OutboxEvent) error
+}
+
+// OutboxRelay переносит события из таблицы outbox в Kafka. Доставка at-least-once:
+// событие помечается опубликованным только после подтверждения записи
+type OutboxRelay struct {
+	repo      IOutboxRepository
+	publisher IEventPublisher
+	batchSize int
+	retention time.Duration
+	logger    *slog.Logger
+}
+
+func NewOutboxRelay(repo IOutboxRepository, publisher IEventPublisher, batchSize int, retention time.Duration, logger *slog.Logger) *OutboxRelay {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &OutboxRelay{
+		repo:      repo,
+		publisher: publisher,
+		batchSize: batchSize,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Relay публикует пачками все накопившиеся события. Возвращает число опубликованных событий
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := r.repo.PublishPending(ctx, r.batchSize, r.publish)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("Relay: %w", err)
+		}
+		if n < r.batchSize {
+			break
+		}
+	}
+
+	if pending, err := r.repo.Pending(ctx); err == nil {
+		metrics.OutboxPending.Set(float64(pending))
+	}
+	return total, nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, events []domain.OutboxEvent) error {
+	err := r.publisher.PublishEvents(ctx, events)
+
+	result := "published"
+	if err != nil {
+		result = "failed"
+	}
+	for _, e := range events {
+		metrics.OutboxEvents.WithLabelValues(e.Type, result).Inc()
+	}
+	return err
+}
+
+// Cleanup удаляет события, опубликованные раньше, чем retention назад
+func (r *OutboxRelay) Cleanup(ctx context.Context) (int64, error) {
+	deleted, err := r.repo.DeletePublished(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		return 0, fmt.Errorf("Cleanup: %w", err)
+	}
+	return deleted, nil
+}
+
+// Run публикует события каждые interval и чистит опубликованные каждые cleanupInterval до отмены контекста
+func (r *OutboxRelay) Run(ctx context.Context, interval, cleanupInterval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		if n, err := r.Relay(ctx); err != nil {
+			r.logger.ErrorContext(ctx, "outbox relay failed", "published", n, "error", err)
+		} else if n > 0 {
+			r.logger.DebugContext(ctx, "outbox events published", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("stopping outbox relay")
+			return
+		case <-cleanup.C:
+			if deleted, err := r.Cleanup(ctx); err != nil {
+				r.logger.ErrorContext(ctx, "outbox cleanup failed", "error", err)
+			} else {
+				r.logger.InfoContext(ctx, "outbox cleaned up", "deleted", deleted)
+			}
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"L0WB/internal/domain"
+	"L0WB/internal/reconciliation"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+const (
+	defaultScanBatchSize     = 500
+	defaultMismatchPageLimit = 50
+	maxMismatchPageLimit     = 500
+)
+
+type IReconciliationRepository interface {
+	ReplaceMismatches(ctx context.Context, keys []domain.OrderKey, mismatches []domain.Mismatch) error
+	ScanTotals(ctx context.Context, after domain.OrderKey, limit int) ([]domain.OrderTotals, error)
+	Report(ctx context.Context, limit, offset int) (domain.MismatchReport, error)
+}
+
+type ReconciliationService struct {
+	repo   IReconciliationRepository
+	logger *slog.Logger
+}
+
+func NewReconciliationService(repo IReconciliationRepository, logger *slog.Logger) *ReconciliationService {
+	return &ReconciliationService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CheckOrder сверяет суммы заказа при приеме и сохраняет расхождения
+func (s *ReconciliationService) CheckOrder(ctx context.Context, order *domain.Order) ([]domain.Mismatch, error) {
+	mismatches := reconciliation.Check(order.Key(), reconciliation.TotalsFromOrder(order), domain.MismatchSourceIngest)
+	if len(mismatches) == 0 {
+		return nil, nil
+	}
+
+	if err := s.repo.ReplaceMismatches(ctx, []domain.OrderKey{order.Key()}, mismatches); err != nil {
+		return nil, fmt.Errorf("CheckOrder: %w", err)
+	}
+
+	s.logger.WarnContext(ctx, "order has payment mismatches", "order_uid", order.ID, "mismatches", len(mismatches))
+	return mismatches, nil
+}
+
+// Scan проходит по всем заказам в БД пачками и пересчитывает расхождения
+func (s *ReconciliationService) Scan(ctx context.Context, batchSize int) (domain.ScanReport, error) {
+	if batchSize <= 0 {
+		batchSize = defaultScanBatchSize
+	}
+
+	var report domain.ScanReport
+	var after domain.OrderKey
+	for {
+		batch, err := s.repo.ScanTotals(ctx, after, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("Scan: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		keys := make([]domain.OrderKey, len(batch))
+		var mismatches []domain.Mismatch
+		for i, t := range batch {
+			keys[i] = t.Order
+			mismatches = append(mismatches, reconciliation.Check(t.Order, t.Totals, domain.MismatchSourceScan)...)
+		}
+
+		if err := s.repo.ReplaceMismatches(ctx, keys, mismatches); err != nil {
+			return report, fmt.Errorf("Scan: %w", err)
+		}
+
+		report.Scanned += int64(len(batch))
+		report.Mismatches += int64(len(mismatches))
+		after = batch[len(batch)-1].Order
+	}
+
+	s.logger.InfoContext(ctx, "reconciliation scan finished", "scanned", report.Scanned, "mismatches", report.Mismatches)
+	return report, nil
+}
+
+func (s *ReconciliationService) Report(ctx context.Context, limit, offset int) (domain.MismatchReport, error) {
+	if limit <= 0 {
+		limit = defaultMismatchPageLimit
+	}
+	if limit > maxMismatchPageLimit {
+		limit = maxMismatchPageLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	report, err := s.repo.Report(ctx, limit, offset)
+	if err != nil {
+		return domain.MismatchReport{}, fmt.Errorf("Report: %w", err)
+	}
+	return report, nil
+}
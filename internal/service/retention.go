@@ -0,0 +1,86 @@
+package service
+
+import (
+	"L0WB/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (s *Service) DeleteOrder(ctx context.Context, key domain.OrderKey) error {
+	customer, err := s.repo.SoftDeleteOrder(ctx, key)
+	if err != nil {
+		return fmt.Errorf("DeleteOrder: %w", err)
+	}
+
+	s.cache.Delete(key)
+	s.summaryCache.Delete(customer)
+	s.logger.InfoContext(ctx, "order soft-deleted", "order_uid", key.OrderUID, "tenant", key.Tenant)
+	return nil
+}
+
+func (s *Service) ApplyRetention(ctx context.Context, policy domain.RetentionPolicy) (domain.RetentionReport, error) {
+	if policy.OlderThan <= 0 {
+		return domain.RetentionReport{}, fmt.Errorf("ApplyRetention: retention period must be positive")
+	}
+
+	before := time.Now().Add(-policy.OlderThan)
+	keys, customers, err := s.repo.ApplyRetention(ctx, policy.Mode, before)
+	if err != nil {
+		return domain.RetentionReport{}, fmt.Errorf("ApplyRetention: %w", err)
+	}
+
+	//Удаленные заказы больше не должны отдаваться из кеша и учитываться в сводках покупателей
+	for _, key := range keys {
+		s.cache.Delete(key)
+	}
+	for _, customer := range customers {
+		s.summaryCache.Delete(customer)
+	}
+
+	s.logger.InfoContext(ctx, "retention applied", "mode", policy.Mode, "orders", len(keys), "before", before)
+	return domain.RetentionReport{
+		Mode:   policy.Mode,
+		Before: before,
+		Orders: keys,
+	}, nil
+}
+
+// RunRetention периодически применяет политику хранения до отмены контекста
+func (s *Service) RunRetention(ctx context.Context, policy domain.RetentionPolicy, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ApplyRetention(ctx, policy); err != nil {
+			s.logger.ErrorContext(ctx, "retention failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("stopping retention worker")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) EraseCustomer(ctx context.Context, customer domain.CustomerKey) (domain.ErasureReport, error) {
+	if customer.CustomerID == "" {
+		return domain.ErasureReport{}, fmt.Errorf("EraseCustomer: empty customer id")
+	}
+
+	report, err := s.repo.EraseCustomerPII(ctx, customer)
+	if err != nil {
+		return domain.ErasureReport{}, fmt.Errorf("EraseCustomer: %w", err)
+	}
+
+	//В кеше лежат заказы с исходными персональными данными
+	for _, orderUID := range report.OrderUIDs {
+		s.cache.Delete(domain.OrderKey{Tenant: customer.Tenant, OrderUID: orderUID})
+	}
+	s.summaryCache.Delete(customer)
+
+	s.logger.InfoContext(ctx, "customer PII erased", "customer_id", customer.CustomerID, "tenant", customer.Tenant, "orders", len(report.OrderUIDs), "deliveries", report.DeliveriesAnonymised)
+	return report, nil
+}
@@ -2,99 +2,143 @@ package service
 
 import (
 	"L0WB/internal/domain"
+	"L0WB/internal/metrics"
 	"context"
 	"fmt"
-	"github.com/google/uuid"
-	"log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
 	"time"
 )
 
+var tracer = otel.Tracer("L0WB/internal/service")
+
 type IOrderCache interface {
-	Set(orderUID uuid.UUID, order *domain.Order)
-	Get(orderUID uuid.UUID) (*domain.Order, bool)
+	Set(key domain.OrderKey, order *domain.Order)
+	Get(key domain.OrderKey) (*domain.Order, bool)
+	Delete(key domain.OrderKey)
+}
+
+type ICustomerSummaryCache interface {
+	Set(customer domain.CustomerKey, summary domain.CustomerSummary)
+	Get(customer domain.CustomerKey) (domain.CustomerSummary, bool)
+	Delete(customer domain.CustomerKey)
 }
 
 type IRepository interface {
-	GetOrder(ctx context.Context, orderUID uuid.UUID) (domain.Order, error)
-	GetAllOrdersByUID(ctx context.Context) ([]uuid.UUID, error)
+	GetOrder(ctx context.Context, key domain.OrderKey) (domain.Order, error)
+	GetAllOrdersByUID(ctx context.Context) ([]domain.OrderKey, error)
 	SaveOrder(ctx context.Context, order *domain.Order) error
+	UpdateOrderStatus(ctx context.Context, update domain.StatusUpdate) (domain.CustomerKey, error)
+	SoftDeleteOrder(ctx context.Context, key domain.OrderKey) (domain.CustomerKey, error)
+	ApplyRetention(ctx context.Context, mode domain.RetentionMode, before time.Time) ([]domain.OrderKey, []domain.CustomerKey, error)
+	EraseCustomerPII(ctx context.Context, customer domain.CustomerKey) (domain.ErasureReport, error)
+	ListOrdersByCustomer(ctx context.Context, customer domain.CustomerKey, limit, offset int) ([]domain.Order, error)
+	GetCustomerSummary(ctx context.Context, customer domain.CustomerKey) (domain.CustomerSummary, error)
 }
 
 type OrderGenerator interface {
-	GenerateFakeOrders(count int) []*domain.CompleteFakeOrder
+	GenerateFakeOrders(strategy string, count int) ([]*domain.CompleteFakeOrder, error)
+}
+
+type IReconciler interface {
+	CheckOrder(ctx context.Context, order *domain.Order) ([]domain.Mismatch, error)
 }
 
 type OrderSender interface {
 	SendOrder(ctx context.Context, order *domain.CompleteFakeOrder) error
+	SendOrders(ctx context.Context, orders []*domain.CompleteFakeOrder) error
 }
 
 type Service struct {
-	repo      IRepository
-	cache     IOrderCache
-	generator OrderGenerator
-	sender    OrderSender
+	repo         IRepository
+	cache        IOrderCache
+	summaryCache ICustomerSummaryCache
+	reconciler   IReconciler
+	generator    OrderGenerator
+	sender       OrderSender
+	logger       *slog.Logger
 }
 
-func NewService(repo IRepository, cache IOrderCache, generator OrderGenerator, sender OrderSender) *Service {
+func NewService(repo IRepository, cache IOrderCache, summaryCache ICustomerSummaryCache, reconciler IReconciler, generator OrderGenerator, sender OrderSender, logger *slog.Logger) *Service {
 	return &Service{
-		repo:      repo,
-		cache:     cache,
-		generator: generator,
-		sender:    sender,
+		repo:         repo,
+		cache:        cache,
+		summaryCache: summaryCache,
+		reconciler:   reconciler,
+		generator:    generator,
+		sender:       sender,
+		logger:       logger,
 	}
 }
 
-func (s *Service) GetOrder(ctx context.Context, orderUID uuid.UUID) (*domain.Order, error) {
+func (s *Service) GetOrder(ctx context.Context, key domain.OrderKey) (*domain.Order, error) {
+	ctx, span := tracer.Start(ctx, "Service.GetOrder", trace.WithAttributes(
+		attribute.String("order.uid", key.OrderUID.String()),
+		attribute.String("order.tenant", key.Tenant),
+	))
+	defer span.End()
+
 	//Пробуем получить данные заказа из кэша
-	if cacheOrder, exist := s.cache.Get(orderUID); exist {
-		fmt.Println("Ордер получен из кэша")
-		log.Printf("Cache hit for order: %s:", orderUID)
+	if cacheOrder, exist := s.cache.Get(key); exist {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		metrics.CacheHit("orders")
+		s.logger.DebugContext(ctx, "cache hit", "order_uid", key.OrderUID, "tenant", key.Tenant)
 		return cacheOrder, nil
 	}
 
-	log.Printf("Cache miss for order: %s:", orderUID)
+	span.SetAttributes(attribute.Bool("cache.hit", false))
+	metrics.CacheMiss("orders")
+	s.logger.DebugContext(ctx, "cache miss", "order_uid", key.OrderUID, "tenant", key.Tenant)
 
 	//Если нет данных в кеше - Получаем из БД
-	order, err := s.repo.GetOrder(ctx, orderUID)
-	fmt.Println("Ордер получен из БД")
+	order, err := s.repo.GetOrder(ctx, key)
 	if err != nil {
-		log.Println("Order not found for DB", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.WarnContext(ctx, "order not found in DB", "order_uid", key.OrderUID, "tenant", key.Tenant, "error", err)
 		return nil, fmt.Errorf("GetOrder: %w", err)
 	}
 
 	//Сохраняем в кеш
-	s.cache.Set(orderUID, &order)
+	s.cache.Set(key, &order)
 
 	return &order, nil
 }
 
 func (s *Service) WarmUpCache(ctx context.Context) error {
-	log.Println("Warming up cache...")
+	s.logger.InfoContext(ctx, "warming up cache")
+	start := time.Now()
+	defer func() {
+		metrics.WarmUpDuration.Set(time.Since(start).Seconds())
+	}()
 
 	//Получаю все ID из БД
-	orderUIDs, err := s.repo.GetAllOrdersByUID(ctx)
+	keys, err := s.repo.GetAllOrdersByUID(ctx)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Found orders by uid: %d", len(orderUIDs))
+	s.logger.InfoContext(ctx, "found orders for warm-up", "count", len(keys))
 
 	//Добавления ордеров в кеш
 
-	for i, orderUID := range orderUIDs {
-		order, err := s.repo.GetOrder(ctx, orderUID)
+	for i, key := range keys {
+		order, err := s.repo.GetOrder(ctx, key)
 		if err != nil {
-			log.Printf("Error loading order %s: %v", orderUID, err)
+			s.logger.WarnContext(ctx, "error loading order", "order_uid", key.OrderUID, "tenant", key.Tenant, "error", err)
 			continue
 		}
 
-		s.cache.Set(orderUID, &order)
+		s.cache.Set(key, &order)
 
 		if (i+1)%100 == 0 {
-			log.Printf("Warmed up %d orders...", i+1)
+			s.logger.DebugContext(ctx, "warm-up progress", "count", i+1)
 		}
 	}
-	log.Printf("Cache warmed up %d orders...", len(orderUIDs))
+	s.logger.InfoContext(ctx, "cache warmed up", "count", len(keys), "duration", time.Since(start))
 	return nil
 }
 
@@ -103,19 +147,75 @@ func (s *Service) SaveOrderFromKafka(ctx context.Context, order *domain.Order) e
 		return err
 	}
 
-	log.Printf("Saved order to kafka: %s", order)
+	//Новый заказ меняет сводку покупателя, а повторно сохраненный еще и лежит в кеше в прежнем виде
+	s.cache.Delete(order.Key())
+	s.summaryCache.Delete(order.Customer())
+
+	//Расхождения в суммах и неизвестная валюта не мешают приему заказа, а попадают в отчет сверки
+	if _, err := s.reconciler.CheckOrder(ctx, order); err != nil {
+		s.logger.ErrorContext(ctx, "reconciliation failed", "order_uid", order.ID, "error", err)
+	}
+
+	s.logger.InfoContext(ctx, "order saved", "order_uid", order.ID, "tenant", order.Tenant)
+	return nil
+}
+
+// UpdateOrderStatusFromKafka применяет смену статуса или отмену заказа
+func (s *Service) UpdateOrderStatusFromKafka(ctx context.Context, update domain.StatusUpdate) error {
+	customer, err := s.repo.UpdateOrderStatus(ctx, update)
+	if err != nil {
+		return fmt.Errorf("UpdateOrderStatusFromKafka: %w", err)
+	}
+
+	//В кешах лежат заказ и сводка покупателя с прежним статусом
+	s.cache.Delete(update.Key())
+	s.summaryCache.Delete(customer)
+
+	s.logger.InfoContext(ctx, "order status updated", "order_uid", update.OrderUID, "tenant", update.Tenant, "status", update.Status)
 	return nil
 }
 
-func (s *Service) GenerateFakeOrdersFromKafka(ctx context.Context, count int) error {
-	orders := s.generator.GenerateFakeOrders(count)
+// GenerateFakeOrdersFromKafka генерирует заказы выбранной стратегией и отправляет их в Kafka
+// со скоростью rate заказов в секунду (0 - без ограничения). Возвращает число отправленных заказов
+func (s *Service) GenerateFakeOrdersFromKafka(ctx context.Context, strategy string, count int, rate float64) (int, error) {
+	if s.generator == nil {
+		return 0, fmt.Errorf("GenerateFakeOrdersFromKafka: %w", domain.ErrGeneratorDisabled)
+	}
+	orders, err := s.generator.GenerateFakeOrders(strategy, count)
+	if err != nil {
+		return 0, fmt.Errorf("GenerateFakeOrdersFromKafka: %w", err)
+	}
+
+	//Без ограничения скорости заказы уходят одним батчем
+	if rate <= 0 {
+		if err := s.sender.SendOrders(ctx, orders); err != nil {
+			return 0, fmt.Errorf("GenerateFakeOrdersFromKafka: %w", err)
+		}
+		return len(orders), nil
+	}
+
+	ticker := time.NewTicker(sendInterval(rate))
+	defer ticker.Stop()
+
+	for i, order := range orders {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return i, ctx.Err()
+			case <-ticker.C:
+			}
+		}
 
-	for _, order := range orders {
 		if err := s.sender.SendOrder(ctx, order); err != nil {
-			return err
+			return i, fmt.Errorf("GenerateFakeOrdersFromKafka: %w", err)
 		}
-		log.Printf("Generated fake order: %s", order.OrderUID)
-		time.Sleep(2 * time.Second)
+		s.logger.DebugContext(ctx, "fake order generated", "order_uid", order.OrderUID)
 	}
-	return nil
+	return len(orders), nil
+}
+
+// sendInterval - пауза между заказами при rate в секунду; при очень большом rate
+// интервал округлялся бы до нуля, а time.NewTicker с нулем паникует
+func sendInterval(rate float64) time.Duration {
+	return max(time.Duration(float64(time.Second)/rate), time.Nanosecond)
 }
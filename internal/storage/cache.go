@@ -2,7 +2,6 @@ package storage
 
 import (
 	"L0WB/internal/domain"
-	"github.com/google/uuid"
 	"sync"
 	"time"
 )
@@ -10,29 +9,35 @@ import (
 type OrderCache struct {
 	mu     sync.RWMutex
 	ttl    time.Duration
-	orders map[uuid.UUID]*domain.Order
+	orders map[domain.OrderKey]*domain.Order
 }
 
 func NewOrderCache(ttl time.Duration) *OrderCache {
 	return &OrderCache{
 		ttl:    ttl,
-		orders: make(map[uuid.UUID]*domain.Order),
+		orders: make(map[domain.OrderKey]*domain.Order),
 	}
 }
 
-func (c *OrderCache) Set(orderUID uuid.UUID, order *domain.Order) {
+func (c *OrderCache) Set(key domain.OrderKey, order *domain.Order) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.orders[orderUID] = order
+	c.orders[key] = order
 }
 
-func (c *OrderCache) Get(orderUID uuid.UUID) (*domain.Order, bool) {
+func (c *OrderCache) Get(key domain.OrderKey) (*domain.Order, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	order, ok := c.orders[orderUID]
+	order, ok := c.orders[key]
 	return order, ok
 }
 
+func (c *OrderCache) Delete(key domain.OrderKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.orders, key)
+}
+
 func (c *OrderCache) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
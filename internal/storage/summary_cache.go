@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"L0WB/internal/domain"
+	"sync"
+	"time"
+)
+
+type summaryEntry struct {
+	summary   domain.CustomerSummary
+	expiresAt time.Time
+}
+
+type CustomerSummaryCache struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	summaries map[domain.CustomerKey]summaryEntry
+}
+
+func NewCustomerSummaryCache(ttl time.Duration) *CustomerSummaryCache {
+	return &CustomerSummaryCache{
+		ttl:       ttl,
+		summaries: make(map[domain.CustomerKey]summaryEntry),
+	}
+}
+
+func (c *CustomerSummaryCache) Set(customer domain.CustomerKey, summary domain.CustomerSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.summaries[customer] = summaryEntry{
+		summary:   summary,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *CustomerSummaryCache) Get(customer domain.CustomerKey) (domain.CustomerSummary, bool) {
+	c.mu.RLock()
+	entry, ok := c.summaries[customer]
+	c.mu.RUnlock()
+	if !ok {
+		return domain.CustomerSummary{}, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		c.Delete(customer)
+		return domain.CustomerSummary{}, false
+	}
+	return entry.summary, true
+}
+
+func (c *CustomerSummaryCache) Delete(customer domain.CustomerKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.summaries, customer)
+}